@@ -21,19 +21,33 @@ func init() {
 	simCmd.Flags().StringVar(&infile, "infile", "input.json", "location of input file (RaidSimRequest in protojson format)")
 	simCmd.Flags().StringVar(&outfile, "outfile", "", "location of output file, defaults to stdout")
 	simCmd.Flags().BoolVar(&verbose, "verbose", false, "print information during runtime")
-	simCmd.MarkFlagRequired("infile")
+	simCmd.Flags().StringVar(&simLink, "link", "", "wowsims link/url to sim instead of the input file")
+	simCmd.Flags().BoolVar(&actionDistributions, "action-distributions", false, "include damage per hit, damage per cast and dps distributions for each action")
 }
 
+var simLink string
+var actionDistributions bool
+
 func simMain(cmd *cobra.Command, args []string) {
-	data, err := os.ReadFile(infile)
-	if err != nil {
-		log.Fatalf("failed to load input json file %q: %v", infile, err)
+	var input *proto.RaidSimRequest
+	var err error
+	if simLink != "" {
+		input, err = decodeLinkRequest(simLink)
+		if err != nil {
+			log.Fatalf("failed to decode link: %s", err)
+		}
+	} else {
+		input = &proto.RaidSimRequest{}
+		if err := readProtoJSON(infile, input); err != nil {
+			log.Fatalf("%s", err)
+		}
 	}
-	input := &proto.RaidSimRequest{}
 
-	err = protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(data, input)
-	if err != nil {
-		log.Fatalf("failed to load input json file: %s", err)
+	if actionDistributions {
+		if input.SimOptions == nil {
+			input.SimOptions = &proto.SimOptions{}
+		}
+		input.SimOptions.SaveActionDistributions = true
 	}
 
 	var output []byte
@@ -33,10 +33,42 @@ func init() {
 	bulkCmd.Flags().StringVar(&replacefile, "replacefile", "", "location of replacement items file. Writes a CSV result of the items replaced instead of JSON")
 	bulkCmd.Flags().StringVar(&outfile, "output", "", "location of output file, defaults to stdout")
 	bulkCmd.Flags().BoolVar(&verbose, "verbose", false, "print information during runtime")
+	bulkCmd.Flags().StringVar(&bulkObjective.kind, "objective", "mean", "what to rank combos by: mean, percentile, consistency (mean minus stdev) or threshold (chance above a dps)")
+	bulkCmd.Flags().Float64Var(&bulkObjective.percentile, "percentile", 0.9, "percentile for --objective percentile")
+	bulkCmd.Flags().Float64Var(&bulkObjective.stdevFactor, "stdev-factor", 1, "stdevs subtracted from the mean for --objective consistency")
+	bulkCmd.Flags().Float64Var(&bulkObjective.dpsThreshold, "dps-threshold", 0, "dps to exceed for --objective threshold")
 	bulkCmd.MarkFlagRequired("infile")
 	bulkCmd.MarkFlagRequired("replacefile")
 }
 
+var bulkObjective struct {
+	kind         string
+	percentile   float64
+	stdevFactor  float64
+	dpsThreshold float64
+}
+
+func parseBulkObjective() (*proto.OptimizationObjective, error) {
+	objective := &proto.OptimizationObjective{
+		Percentile:   bulkObjective.percentile,
+		StdevFactor:  bulkObjective.stdevFactor,
+		DpsThreshold: bulkObjective.dpsThreshold,
+	}
+	switch bulkObjective.kind {
+	case "mean":
+		objective.Type = proto.OptimizationObjectiveType_ObjectiveMean
+	case "percentile":
+		objective.Type = proto.OptimizationObjectiveType_ObjectivePercentile
+	case "consistency":
+		objective.Type = proto.OptimizationObjectiveType_ObjectiveMeanMinusStdev
+	case "threshold":
+		objective.Type = proto.OptimizationObjectiveType_ObjectiveChanceAboveThreshold
+	default:
+		return nil, fmt.Errorf("unknown objective %q", bulkObjective.kind)
+	}
+	return objective, nil
+}
+
 func bulkSimMain(cmd *cobra.Command, args []string) {
 	data, err := os.ReadFile(infile)
 	if err != nil {
@@ -49,7 +81,12 @@ func bulkSimMain(cmd *cobra.Command, args []string) {
 		log.Fatalf("failed to load input json file: %s", err)
 	}
 
-	output := BulkSim(input, replacefile, verbose)
+	objective, err := parseBulkObjective()
+	if err != nil {
+		log.Fatalf("%s", err)
+	}
+
+	output := BulkSim(input, replacefile, objective, verbose)
 
 	if outfile == "" {
 		print(string(output))
@@ -75,7 +112,7 @@ type ReplaceIter struct {
 	Slots []proto.ItemSlot // Slots for each sub item
 }
 
-func BulkSim(input *proto.RaidSimRequest, replaceFile string, verbose bool) string {
+func BulkSim(input *proto.RaidSimRequest, replaceFile string, objective *proto.OptimizationObjective, verbose bool) string {
 	// 1. Load up all the sim data we need
 	replaceData, err := os.ReadFile(replaceFile)
 	if err != nil {
@@ -94,6 +131,7 @@ func BulkSim(input *proto.RaidSimRequest, replaceFile string, verbose bool) stri
 			Items:              replaceInput.Items,
 			IterationsPerCombo: input.SimOptions.Iterations,
 			FastMode:           replaceInput.FastMode,
+			Objective:          objective,
 		},
 	}
 	progress := make(chan *proto.ProgressMetrics, 100)
@@ -113,7 +151,7 @@ func BulkSim(input *proto.RaidSimRequest, replaceFile string, verbose bool) stri
 				if status.FinalBulkResult.Error != nil {
 					fmt.Printf("Failed: %s\n", status.FinalBulkResult.Error.Message)
 				} else {
-					return printCombos(status.FinalBulkResult)
+					return printCombos(status.FinalBulkResult, objective)
 				}
 			}
 
@@ -151,22 +189,25 @@ func BulkSim(input *proto.RaidSimRequest, replaceFile string, verbose bool) stri
 	}
 }
 
-func printCombos(results *proto.BulkSimResult) string {
+// Prints one combo per line as items,dps. The score is only added as a third column when combos
+// are ranked by something other than their mean dps, so the default output keeps its format.
+func printCombos(results *proto.BulkSimResult, objective *proto.OptimizationObjective) string {
+	withScore := objective.GetType() != proto.OptimizationObjectiveType_ObjectiveMean
 	result := ""
 	foundBase := false
 	for i := 0; i < len(results.Results); i++ {
 		if len(results.Results[i].ItemsAdded) == 0 {
 			foundBase = true
 		}
-		result += printCombo(results.Results[i])
+		result += printCombo(results.Results[i], withScore)
 	}
 	if !foundBase {
-		result += fmt.Sprintf("[BASE RESULT],%0.1f\n", results.EquippedGearResult.UnitMetrics.Dps.Avg)
+		result += printComboLine("[BASE RESULT]", results.EquippedGearResult.UnitMetrics.Dps.Avg, results.EquippedGearResult.Score, withScore)
 	}
 	return result
 }
 
-func printCombo(combo *proto.BulkComboResult) string {
+func printCombo(combo *proto.BulkComboResult, withScore bool) string {
 	itemtext := "["
 	if len(combo.ItemsAdded) == 0 {
 		itemtext += "BASE RESULT"
@@ -178,5 +219,12 @@ func printCombo(combo *proto.BulkComboResult) string {
 		itemtext += fmt.Sprintf("%s@%s", core.ItemsByID[item.Item.Id].Name, item.Slot.String())
 	}
 	itemtext += "]"
-	return fmt.Sprintf("%s,%0.1f\n", itemtext, combo.UnitMetrics.Dps.Avg)
+	return printComboLine(itemtext, combo.UnitMetrics.Dps.Avg, combo.Score, withScore)
+}
+
+func printComboLine(itemtext string, dps float64, score float64, withScore bool) string {
+	if withScore {
+		return fmt.Sprintf("%s,%0.1f,%0.3f\n", itemtext, dps, score)
+	}
+	return fmt.Sprintf("%s,%0.1f\n", itemtext, dps)
 }
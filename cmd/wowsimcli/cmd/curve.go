@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/wowsims/sod/sim/core"
+	"github.com/wowsims/sod/sim/core/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var curveCmd = &cobra.Command{
+	Use:   "curve",
+	Short: "sim dps across a range of a single stat and detect caps",
+	Long:  "sim dps across a range of a single stat and detect caps. Flags override the values in the input file.",
+	RunE:  curveMain,
+}
+
+var curveFlags struct {
+	infile  string
+	outfile string
+	stat    string
+	min     float64
+	max     float64
+	points  int32
+	format  string
+}
+
+func init() {
+	f := curveCmd.Flags()
+	f.StringVar(&curveFlags.infile, "infile", "", "location of input file (StatCurveRequest in protojson format)")
+	f.StringVar(&curveFlags.outfile, "outfile", "", "location of output file, defaults to stdout")
+	f.StringVar(&curveFlags.stat, "stat", "", "stat to vary, e.g. meleehit")
+	f.Float64Var(&curveFlags.min, "min", 0, "lowest bonus amount of the stat")
+	f.Float64Var(&curveFlags.max, "max", 0, "highest bonus amount of the stat")
+	f.Int32Var(&curveFlags.points, "points", 0, "number of points to sim")
+	f.StringVar(&curveFlags.format, "format", "text", "output format, text or json")
+	curveCmd.MarkFlagRequired("infile")
+}
+
+func curveMain(cmd *cobra.Command, args []string) error {
+	data, err := os.ReadFile(curveFlags.infile)
+	if err != nil {
+		return fmt.Errorf("failed to load input json file %q: %w", curveFlags.infile, err)
+	}
+	request := &proto.StatCurveRequest{}
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, request); err != nil {
+		return fmt.Errorf("failed to parse input json file: %w", err)
+	}
+
+	if curveFlags.stat != "" {
+		stat, err := parseEnumFlag(proto.Stat_value, "Stat", curveFlags.stat)
+		if err != nil {
+			return err
+		}
+		request.Stat = proto.Stat(stat)
+	}
+	if cmd.Flags().Changed("min") {
+		request.MinValue = curveFlags.min
+	}
+	if cmd.Flags().Changed("max") {
+		request.MaxValue = curveFlags.max
+	}
+	if curveFlags.points != 0 {
+		request.NumPoints = curveFlags.points
+	}
+
+	result := core.StatCurve(request)
+	if result.Error != nil {
+		return fmt.Errorf("stat curve failed: %s", result.Error.Message)
+	}
+
+	var output []byte
+	switch strings.ToLower(curveFlags.format) {
+	case "json":
+		output, err = protojson.MarshalOptions{Multiline: true}.Marshal(result)
+		if err != nil {
+			return fmt.Errorf("failed to marshal results: %w", err)
+		}
+	case "text":
+		output = statCurveText(result)
+	default:
+		return fmt.Errorf("unknown output format %q", curveFlags.format)
+	}
+
+	if curveFlags.outfile == "" {
+		fmt.Println(string(output))
+		return nil
+	}
+	return os.WriteFile(curveFlags.outfile, output, 0666)
+}
+
+func statCurveText(result *proto.StatCurveResult) []byte {
+	buffer := new(bytes.Buffer)
+	fmt.Fprintf(buffer, "%10s %10s %10s %10s\n", "Value", "DPS", "Low", "High")
+	for _, point := range result.Points {
+		fmt.Fprintf(buffer, "%+10g %10.2f %10.2f %10.2f\n", point.Value, point.Dps, point.DpsLow, point.DpsHigh)
+	}
+	if len(result.Knees) == 0 {
+		buffer.WriteString("\nNo caps detected.\n")
+	}
+	for _, knee := range result.Knees {
+		fmt.Fprintf(buffer, "\n%s", knee.Description)
+	}
+	return buffer.Bytes()
+}
@@ -0,0 +1,263 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/wowsims/sod/assets/database"
+	"github.com/wowsims/sod/sim/core"
+	"github.com/wowsims/sod/sim/core/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var dbCmd = &cobra.Command{
+	Use:   "db",
+	Short: "query the local item database",
+	Long:  "query the local item database",
+}
+
+var dbSearchCmd = &cobra.Command{
+	Use:   "search",
+	Short: "search items, enchants and runes",
+	Long:  "search items, enchants and runes. Enum flags accept either the full proto name (ItemTypeWeapon) or its suffix (weapon).",
+	RunE:  dbSearchMain,
+}
+
+var dbSearchFlags struct {
+	name              string
+	itemType          string
+	armorTypes        []string
+	weaponTypes       []string
+	handTypes         []string
+	rangedWeaponTypes []string
+	class             string
+	phases            []int32
+	minQuality        string
+	minStats          []string
+	zoneId            int32
+	npcId             int32
+	enchants          bool
+	runes             bool
+	limit             int32
+	format            string
+	outfile           string
+}
+
+func init() {
+	f := dbSearchCmd.Flags()
+	f.StringVar(&dbSearchFlags.name, "name", "", "case-insensitive substring of the name")
+	f.StringVar(&dbSearchFlags.itemType, "type", "", "item slot type, e.g. weapon, head, trinket")
+	f.StringSliceVar(&dbSearchFlags.armorTypes, "armor", nil, "armor types, e.g. leather,mail")
+	f.StringSliceVar(&dbSearchFlags.weaponTypes, "weapon", nil, "weapon types, e.g. mace,sword")
+	f.StringSliceVar(&dbSearchFlags.handTypes, "hand", nil, "hand types, e.g. twohand,onehand")
+	f.StringSliceVar(&dbSearchFlags.rangedWeaponTypes, "ranged", nil, "ranged weapon types, e.g. bow,gun")
+	f.StringVar(&dbSearchFlags.class, "class", "", "only entries usable by this class, e.g. warrior")
+	f.Int32SliceVar(&dbSearchFlags.phases, "phase", nil, "phases to include")
+	f.StringVar(&dbSearchFlags.minQuality, "min-quality", "", "minimum item quality, e.g. rare")
+	f.StringSliceVar(&dbSearchFlags.minStats, "min-stat", nil, "minimum stat values as Stat=value, e.g. meleehit=1")
+	f.Int32Var(&dbSearchFlags.zoneId, "zone", 0, "only items dropped or sold in this zone ID")
+	f.Int32Var(&dbSearchFlags.npcId, "npc", 0, "only items dropped or sold by this NPC ID")
+	f.BoolVar(&dbSearchFlags.enchants, "enchants", false, "search enchants instead of items")
+	f.BoolVar(&dbSearchFlags.runes, "runes", false, "search runes instead of items")
+	f.Int32Var(&dbSearchFlags.limit, "limit", 0, "maximum number of results of each kind, 0 for no limit")
+	f.StringVar(&dbSearchFlags.format, "format", "json", "output format, json or csv")
+	f.StringVar(&dbSearchFlags.outfile, "outfile", "", "location of output file, defaults to stdout")
+
+	dbCmd.AddCommand(dbSearchCmd)
+}
+
+func dbSearchMain(cmd *cobra.Command, args []string) error {
+	request, err := buildDatabaseSearchRequest()
+	if err != nil {
+		return err
+	}
+
+	result := core.SearchDatabase(database.Load(), request)
+	if result.ErrorResult != "" {
+		return fmt.Errorf("search failed: %s", result.ErrorResult)
+	}
+
+	var output []byte
+	switch strings.ToLower(dbSearchFlags.format) {
+	case "json":
+		output, err = protojson.MarshalOptions{Multiline: true}.Marshal(result)
+	case "csv":
+		output, err = databaseSearchResultToCSV(result)
+	default:
+		return fmt.Errorf("unknown output format %q", dbSearchFlags.format)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to format results: %w", err)
+	}
+
+	if dbSearchFlags.outfile == "" {
+		fmt.Println(string(output))
+		return nil
+	}
+	return os.WriteFile(dbSearchFlags.outfile, output, 0666)
+}
+
+func buildDatabaseSearchRequest() (*proto.DatabaseSearchRequest, error) {
+	f := &dbSearchFlags
+	request := &proto.DatabaseSearchRequest{
+		Name:            f.name,
+		Phases:          f.phases,
+		ZoneId:          f.zoneId,
+		NpcId:           f.npcId,
+		IncludeEnchants: f.enchants,
+		IncludeRunes:    f.runes,
+		Limit:           f.limit,
+	}
+
+	if f.itemType != "" {
+		v, err := parseEnumFlag(proto.ItemType_value, "ItemType", f.itemType)
+		if err != nil {
+			return nil, err
+		}
+		request.Type = proto.ItemType(v)
+	}
+	if f.class != "" {
+		v, err := parseEnumFlag(proto.Class_value, "Class", f.class)
+		if err != nil {
+			return nil, err
+		}
+		request.Class = proto.Class(v)
+	}
+	if f.minQuality != "" {
+		v, err := parseEnumFlag(proto.ItemQuality_value, "ItemQuality", f.minQuality)
+		if err != nil {
+			return nil, err
+		}
+		request.MinQuality = proto.ItemQuality(v)
+	}
+	for _, s := range f.armorTypes {
+		v, err := parseEnumFlag(proto.ArmorType_value, "ArmorType", s)
+		if err != nil {
+			return nil, err
+		}
+		request.ArmorTypes = append(request.ArmorTypes, proto.ArmorType(v))
+	}
+	for _, s := range f.weaponTypes {
+		v, err := parseEnumFlag(proto.WeaponType_value, "WeaponType", s)
+		if err != nil {
+			return nil, err
+		}
+		request.WeaponTypes = append(request.WeaponTypes, proto.WeaponType(v))
+	}
+	for _, s := range f.handTypes {
+		v, err := parseEnumFlag(proto.HandType_value, "HandType", s)
+		if err != nil {
+			return nil, err
+		}
+		request.HandTypes = append(request.HandTypes, proto.HandType(v))
+	}
+	for _, s := range f.rangedWeaponTypes {
+		v, err := parseEnumFlag(proto.RangedWeaponType_value, "RangedWeaponType", s)
+		if err != nil {
+			return nil, err
+		}
+		request.RangedWeaponTypes = append(request.RangedWeaponTypes, proto.RangedWeaponType(v))
+	}
+
+	if len(f.minStats) > 0 {
+		request.MinStats = make([]float64, len(proto.Stat_name))
+		for _, s := range f.minStats {
+			statName, valueStr, ok := strings.Cut(s, "=")
+			if !ok {
+				return nil, fmt.Errorf("invalid min-stat %q, expected Stat=value", s)
+			}
+			stat, err := parseEnumFlag(proto.Stat_value, "Stat", statName)
+			if err != nil {
+				return nil, err
+			}
+			value, err := strconv.ParseFloat(valueStr, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid min-stat value %q: %w", valueStr, err)
+			}
+			request.MinStats[stat] = value
+		}
+	}
+
+	return request, nil
+}
+
+// Accepts either the full proto enum name or the name without its type prefix, case-insensitively.
+func parseEnumFlag(values map[string]int32, prefix string, s string) (int32, error) {
+	want := strings.ToLower(strings.ReplaceAll(s, "-", ""))
+	for name, v := range values {
+		lowerName := strings.ToLower(name)
+		if lowerName == want || strings.TrimPrefix(lowerName, strings.ToLower(prefix)) == want {
+			return v, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown %s %q", prefix, s)
+}
+
+func databaseSearchResultToCSV(result *proto.DatabaseSearchResult) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	statHeaders := make([]string, len(proto.Stat_name))
+	for i := range statHeaders {
+		statHeaders[i] = strings.TrimPrefix(proto.Stat(i).String(), "Stat")
+	}
+	statColumns := func(values []float64) []string {
+		columns := make([]string, len(statHeaders))
+		for i := range columns {
+			if i < len(values) && values[i] != 0 {
+				columns[i] = strconv.FormatFloat(values[i], 'f', -1, 64)
+			}
+		}
+		return columns
+	}
+
+	if len(result.Items) > 0 {
+		w.Write(append([]string{"kind", "id", "name", "type", "armor_type", "weapon_type", "hand_type", "ranged_weapon_type", "phase", "ilvl", "quality"}, statHeaders...))
+		for _, item := range result.Items {
+			w.Write(append([]string{
+				"item",
+				strconv.Itoa(int(item.Id)),
+				item.Name,
+				item.Type.String(),
+				item.ArmorType.String(),
+				item.WeaponType.String(),
+				item.HandType.String(),
+				item.RangedWeaponType.String(),
+				strconv.Itoa(int(item.Phase)),
+				strconv.Itoa(int(item.Ilvl)),
+				item.Quality.String(),
+			}, statColumns(item.Stats)...))
+		}
+	}
+
+	if len(result.Enchants) > 0 {
+		w.Write(append([]string{"kind", "effect_id", "name", "type", "item_id", "spell_id", "phase", "quality"}, statHeaders...))
+		for _, enchant := range result.Enchants {
+			w.Write(append([]string{
+				"enchant",
+				strconv.Itoa(int(enchant.EffectId)),
+				enchant.Name,
+				enchant.Type.String(),
+				strconv.Itoa(int(enchant.ItemId)),
+				strconv.Itoa(int(enchant.SpellId)),
+				strconv.Itoa(int(enchant.Phase)),
+				enchant.Quality.String(),
+			}, statColumns(enchant.Stats)...))
+		}
+	}
+
+	if len(result.Runes) > 0 {
+		w.Write([]string{"kind", "id", "name", "type"})
+		for _, uiRune := range result.Runes {
+			w.Write([]string{"rune", strconv.Itoa(int(uiRune.Id)), uiRune.Name, uiRune.Type.String()})
+		}
+	}
+
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
@@ -1,15 +1,12 @@
 package cmd
 
 import (
-	"bytes"
-	"compress/zlib"
-	"encoding/base64"
-	"errors"
 	"fmt"
-	"strings"
+	"os"
 
 	"github.com/spf13/cobra"
 	"github.com/wowsims/sod/sim/core/proto"
+	"github.com/wowsims/sod/sim/core/sharelink"
 	"google.golang.org/protobuf/encoding/protojson"
 	goproto "google.golang.org/protobuf/proto"
 )
@@ -24,44 +21,36 @@ var decodeLinkCmd = &cobra.Command{
 	},
 }
 
-var errInvalidLink = errors.New("invalid wowsims export link")
+var decodeLinkAsRequest bool
 
-func decodeLink(link string) error {
-	parts := strings.Split(link, "#")
-	switch {
-	case len(parts) != 2:
-		return errInvalidLink
-	case parts[1] == "":
-		return errInvalidLink
-	}
+func init() {
+	decodeLinkCmd.Flags().BoolVar(&decodeLinkAsRequest, "request", false, "print the RaidSimRequest the link runs instead of its settings")
+}
 
-	raw, err := base64.StdEncoding.DecodeString(parts[1])
-	if err != nil {
-		return fmt.Errorf("cannot decode proto from link: %w", err)
+func decodeLink(link string) error {
+	var message goproto.Message
+	var err error
+	if decodeLinkAsRequest {
+		message, err = decodeLinkRequest(link)
+	} else {
+		message, err = sharelink.Decode(link)
 	}
-
-	r, err := zlib.NewReader(bytes.NewReader(raw))
 	if err != nil {
-		return fmt.Errorf("cannot create zlib reader: %w", err)
+		return err
 	}
-	defer r.Close()
 
-	var buf bytes.Buffer
-	if _, err := buf.ReadFrom(r); err != nil {
-		return fmt.Errorf("reading zlib data failed: %w", err)
-	}
+	fmt.Println(protojson.Format(message))
+	return nil
+}
 
-	var settings goproto.Message
-	if strings.Contains(link, "/raid/") {
-		settings = &proto.RaidSimSettings{}
-	} else {
-		settings = &proto.IndividualSimSettings{}
+// Decodes the request of a link, warning about rotations only the web UI can generate.
+func decodeLinkRequest(link string) (*proto.RaidSimRequest, error) {
+	request, err := sharelink.DecodeRequest(link)
+	if err != nil {
+		return nil, err
 	}
-
-	if err := goproto.Unmarshal(buf.Bytes(), settings); err != nil {
-		return fmt.Errorf("cannot unmarshal raw proto: %w", err)
+	for _, name := range sharelink.UnresolvedRotations(request) {
+		fmt.Fprintf(os.Stderr, "warning: %q uses an auto or simple rotation, simming the APL stored in the link instead\n", name)
 	}
-
-	fmt.Println(protojson.Format(goproto.Message(settings)))
-	return nil
+	return request, nil
 }
@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/wowsims/sod/sim/core"
+	"github.com/wowsims/sod/sim/core/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+	goproto "google.golang.org/protobuf/proto"
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff [before] [after]",
+	Short: "explain the dps difference between two sim results",
+	Long:  "explain the dps difference between two sim results (RaidSimResult in protojson format), attributing it to actions, aura uptimes and resources. With --requests both files are RaidSimRequests which are simmed first.",
+	Args:  cobra.ExactArgs(2),
+	RunE:  diffMain,
+}
+
+var diffFlags struct {
+	requests bool
+	party    int32
+	player   int32
+	top      int
+	outfile  string
+	format   string
+}
+
+func init() {
+	f := diffCmd.Flags()
+	f.BoolVar(&diffFlags.requests, "requests", false, "treat the files as RaidSimRequests and sim both with the same seed")
+	f.Int32Var(&diffFlags.party, "party", 0, "party index of the player to explain")
+	f.Int32Var(&diffFlags.player, "player", 0, "index of the player to explain within its party")
+	f.IntVar(&diffFlags.top, "top", 10, "number of actions, auras and resources to print in text format, 0 for all")
+	f.StringVar(&diffFlags.outfile, "outfile", "", "location of output file, defaults to stdout")
+	f.StringVar(&diffFlags.format, "format", "text", "output format, text or json")
+}
+
+func diffMain(cmd *cobra.Command, args []string) error {
+	request := &proto.ResultDiffRequest{
+		PartyIndex:  diffFlags.party,
+		PlayerIndex: diffFlags.player,
+	}
+	if diffFlags.requests {
+		request.BeforeRequest = &proto.RaidSimRequest{}
+		request.AfterRequest = &proto.RaidSimRequest{}
+		if err := readProtoJSON(args[0], request.BeforeRequest); err != nil {
+			return err
+		}
+		if err := readProtoJSON(args[1], request.AfterRequest); err != nil {
+			return err
+		}
+	} else {
+		request.Before = &proto.RaidSimResult{}
+		request.After = &proto.RaidSimResult{}
+		if err := readProtoJSON(args[0], request.Before); err != nil {
+			return err
+		}
+		if err := readProtoJSON(args[1], request.After); err != nil {
+			return err
+		}
+	}
+
+	result := core.ResultDiff(request)
+	if result.Error != nil {
+		return fmt.Errorf("diff failed: %s", result.Error.Message)
+	}
+
+	var output []byte
+	var err error
+	switch strings.ToLower(diffFlags.format) {
+	case "json":
+		output, err = protojson.MarshalOptions{Multiline: true}.Marshal(result)
+		if err != nil {
+			return fmt.Errorf("failed to marshal results: %w", err)
+		}
+	case "text":
+		output = resultDiffText(result, diffFlags.top)
+	default:
+		return fmt.Errorf("unknown output format %q", diffFlags.format)
+	}
+
+	if diffFlags.outfile == "" {
+		fmt.Println(string(output))
+		return nil
+	}
+	return os.WriteFile(diffFlags.outfile, output, 0666)
+}
+
+func readProtoJSON(path string, message goproto.Message) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to load input json file %q: %w", path, err)
+	}
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, message); err != nil {
+		return fmt.Errorf("failed to parse input json file %q: %w", path, err)
+	}
+	return nil
+}
+
+func resultDiffName(id *proto.ActionID, petName string) string {
+	name := core.ProtoToActionID(id).String()
+	if petName != "" {
+		name = petName + " " + name
+	}
+	return name
+}
+
+func resultDiffText(result *proto.ResultDiffResult, top int) []byte {
+	limit := func(n int) int {
+		if top > 0 && top < n {
+			return top
+		}
+		return n
+	}
+
+	buffer := new(bytes.Buffer)
+	fmt.Fprintf(buffer, "DPS %.2f -> %.2f (%+.2f)\n", result.DpsBefore, result.DpsAfter, result.DpsAfter-result.DpsBefore)
+
+	fmt.Fprintf(buffer, "\n%-40s %10s %10s %10s %14s %14s %14s\n", "Action", "DPS", "From casts", "From dmg", "Casts", "Hit %", "Crit %")
+	for _, action := range result.Actions[:limit(len(result.Actions))] {
+		fmt.Fprintf(buffer, "%-40s %+10.2f %+10.2f %+10.2f %6.1f->%-6.1f %6.1f->%-6.1f %6.1f->%-6.1f\n",
+			resultDiffName(action.Id, action.PetName),
+			action.DpsAfter-action.DpsBefore, action.DpsDeltaFromCasts, action.DpsDeltaFromDamagePerCast,
+			action.CastsBefore, action.CastsAfter,
+			action.HitRateBefore*100, action.HitRateAfter*100,
+			action.CritRateBefore*100, action.CritRateAfter*100)
+	}
+	fmt.Fprintf(buffer, "%-40s %+10.2f\n", "Fight length variance", result.UnexplainedDpsDelta)
+
+	if len(result.Auras) > 0 {
+		fmt.Fprintf(buffer, "\n%-40s %15s %15s\n", "Aura", "Uptime %", "Procs")
+		for _, aura := range result.Auras[:limit(len(result.Auras))] {
+			fmt.Fprintf(buffer, "%-40s %6.1f->%-7.1f %6.1f->%-7.1f\n",
+				resultDiffName(aura.Id, aura.PetName),
+				aura.UptimeBefore*100, aura.UptimeAfter*100,
+				aura.ProcsBefore, aura.ProcsAfter)
+		}
+	}
+
+	if len(result.Resources) > 0 {
+		fmt.Fprintf(buffer, "\n%-40s %-24s %17s\n", "Resource", "Type", "Gain/s")
+		for _, resource := range result.Resources[:limit(len(result.Resources))] {
+			fmt.Fprintf(buffer, "%-40s %-24s %7.2f->%-8.2f\n",
+				resultDiffName(resource.Id, resource.PetName), resource.Type,
+				resource.GainPerSecondBefore, resource.GainPerSecondAfter)
+		}
+	}
+	return buffer.Bytes()
+}
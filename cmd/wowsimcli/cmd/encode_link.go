@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wowsims/sod/sim/core/proto"
+	"github.com/wowsims/sod/sim/core/sharelink"
+)
+
+var encodeLinkCmd = &cobra.Command{
+	Use:   "encodelink",
+	Short: "encode a sim request as wowsims link/url",
+	Long:  "encode a sim request as wowsims link/url. Requests with a single player link to the individual sim of their spec, all others to the raid sim.",
+	RunE:  encodeLinkMain,
+}
+
+var encodeLinkFlags struct {
+	infile  string
+	baseURL string
+	raid    bool
+}
+
+func init() {
+	f := encodeLinkCmd.Flags()
+	f.StringVar(&encodeLinkFlags.infile, "infile", "input.json", "location of input file (RaidSimRequest in protojson format)")
+	f.StringVar(&encodeLinkFlags.baseURL, "base-url", sharelink.DefaultBaseURL, "root url of the sims site")
+	f.BoolVar(&encodeLinkFlags.raid, "raid", false, "always link to the raid sim")
+}
+
+func encodeLinkMain(cmd *cobra.Command, args []string) error {
+	request := &proto.RaidSimRequest{}
+	if err := readProtoJSON(encodeLinkFlags.infile, request); err != nil {
+		return err
+	}
+
+	encode := sharelink.EncodeRequest
+	if encodeLinkFlags.raid {
+		encode = sharelink.EncodeRaidRequest
+	}
+	link, err := encode(encodeLinkFlags.baseURL, request)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(link)
+	return nil
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/wowsims/sod/sim/core"
+	"github.com/wowsims/sod/sim/core/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "import a character from an in-game addon export",
+	Long:  "import a character from an in-game addon export. Outputs a Player in protojson format, warnings are printed to stderr.",
+	RunE:  importMain,
+}
+
+var importFlags struct {
+	infile  string
+	outfile string
+}
+
+func init() {
+	importCmd.Flags().StringVar(&importFlags.infile, "infile", "", "location of the addon export, defaults to stdin")
+	importCmd.Flags().StringVar(&importFlags.outfile, "outfile", "", "location of output file, defaults to stdout")
+}
+
+func importMain(cmd *cobra.Command, args []string) error {
+	var data []byte
+	var err error
+	if importFlags.infile == "" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(importFlags.infile)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read import data: %w", err)
+	}
+
+	result := core.ImportCharacter(&proto.CharacterImportRequest{Data: string(data)})
+	for _, warning := range result.Warnings {
+		fmt.Fprintln(os.Stderr, "warning:", warning)
+	}
+	if result.ErrorResult != "" {
+		return fmt.Errorf("import failed: %s", result.ErrorResult)
+	}
+
+	output, err := protojson.MarshalOptions{Multiline: true}.Marshal(result.Player)
+	if err != nil {
+		return fmt.Errorf("failed to marshal player: %w", err)
+	}
+
+	if importFlags.outfile == "" {
+		fmt.Println(string(output))
+		return nil
+	}
+	return os.WriteFile(importFlags.outfile, output, 0666)
+}
@@ -18,6 +18,11 @@ func Execute(version string) {
 	rootCmd.AddCommand(simCmd)
 	rootCmd.AddCommand(bulkCmd)
 	rootCmd.AddCommand(decodeLinkCmd)
+	rootCmd.AddCommand(encodeLinkCmd)
+	rootCmd.AddCommand(dbCmd)
+	rootCmd.AddCommand(importCmd)
+	rootCmd.AddCommand(curveCmd)
+	rootCmd.AddCommand(diffCmd)
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintln(os.Stderr, err)
@@ -0,0 +1,124 @@
+package core
+
+import (
+	"math"
+	"time"
+
+	"github.com/wowsims/sod/sim/core/proto"
+	"github.com/wowsims/sod/sim/core/simsignals"
+	googleProto "google.golang.org/protobuf/proto"
+)
+
+const defaultAdaptiveMinIterations = 1000
+
+// Number of iterations between precision checks once the minimum is reached.
+const adaptiveBatchIterations = 1000
+
+func isAdaptive(options *proto.SimOptions) bool {
+	return options.TargetRelativeError > 0
+}
+
+func adaptiveMinIterations(options *proto.SimOptions) int32 {
+	minIterations := options.MinIterations
+	if minIterations <= 0 {
+		minIterations = defaultAdaptiveMinIterations
+	}
+	return min(minIterations, options.Iterations)
+}
+
+func relativeError(mean float64, stdev float64, n int) float64 {
+	if mean == 0 || n == 0 {
+		return 0
+	}
+	return stdev / math.Sqrt(float64(n)) / math.Abs(mean)
+}
+
+func (raid *Raid) relativeError() float64 {
+	metrics := &raid.dpsMetrics
+	if metrics.sum == 0 {
+		metrics = &raid.hpsMetrics
+	}
+	mean, stdev := metrics.meanAndStdDev()
+	return relativeError(mean, stdev, metrics.n)
+}
+
+func raidMetricsRelativeError(metrics *proto.RaidMetrics, iterations int32) float64 {
+	dist := metrics.Dps
+	if dist.Avg == 0 {
+		dist = metrics.Hps
+	}
+	return relativeError(dist.Avg, dist.Stdev, int(iterations))
+}
+
+// Runs concurrent sims in rounds until the target precision is reached. Every round continues
+// the seed sequence of the previous one, so the result matches a single sim with the same number
+// of iterations, including the order of saved values.
+func runSimConcurrentAdaptive(request *proto.RaidSimRequest, progress chan *proto.ProgressMetrics, signals simsignals.Signals) *proto.RaidSimResult {
+	options := request.SimOptions
+	maxIterations := options.Iterations
+	if options.RandomSeed == 0 {
+		options.RandomSeed = time.Now().UnixNano()
+	}
+
+	var roundResults []*proto.RaidSimResult
+	var result *proto.RaidSimResult
+	var iterationsDone int32
+	for iterationsDone < maxIterations {
+		roundIterations := TernaryInt32(iterationsDone == 0, adaptiveMinIterations(options), adaptiveBatchIterations)
+		roundRequest := googleProto.Clone(request).(*proto.RaidSimRequest)
+		roundRequest.SimOptions.Iterations = min(roundIterations, maxIterations-iterationsDone)
+		roundRequest.SimOptions.RandomSeed = options.RandomSeed + int64(iterationsDone)
+		roundRequest.SimOptions.TargetRelativeError = 0
+		if iterationsDone > 0 {
+			roundRequest.SimOptions.DebugFirstIteration = false
+		}
+
+		roundProgress := make(chan *proto.ProgressMetrics, 100)
+		go runSimConcurrent(roundRequest, roundProgress, signals)
+
+		var roundResult *proto.RaidSimResult
+		for metrics := range roundProgress {
+			if metrics.FinalRaidResult != nil {
+				roundResult = metrics.FinalRaidResult
+				break
+			}
+			if progress != nil {
+				progress <- &proto.ProgressMetrics{
+					TotalIterations:     maxIterations,
+					CompletedIterations: iterationsDone + metrics.CompletedIterations,
+					Dps:                 metrics.Dps,
+					Hps:                 metrics.Hps,
+				}
+			}
+		}
+		if roundResult == nil || roundResult.Error != nil {
+			if roundResult == nil {
+				roundResult = &proto.RaidSimResult{Error: &proto.ErrorOutcome{Message: "Missing sim result!"}}
+			}
+			if progress != nil {
+				progress <- &proto.ProgressMetrics{FinalRaidResult: roundResult}
+				close(progress)
+			}
+			return roundResult
+		}
+
+		roundResults = append(roundResults, roundResult)
+		iterationsDone += roundResult.IterationsDone
+		result = CombineConcurrentSimResults(roundResults, options.Debug)
+		if result.RelativeError <= options.TargetRelativeError {
+			break
+		}
+	}
+
+	if progress != nil {
+		progress <- &proto.ProgressMetrics{
+			TotalIterations:     maxIterations,
+			CompletedIterations: iterationsDone,
+			Dps:                 result.RaidMetrics.Dps.Avg,
+			Hps:                 result.RaidMetrics.Hps.Avg,
+			FinalRaidResult:     result,
+		}
+		close(progress)
+	}
+	return result
+}
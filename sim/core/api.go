@@ -51,6 +51,48 @@ func StatWeightsAsync(request *proto.StatWeightsRequest, progress chan *proto.Pr
 	}()
 }
 
+/**
+ * Returns DPS at evenly spaced amounts of a single stat, along with detected caps.
+ */
+func StatCurve(request *proto.StatCurveRequest) *proto.StatCurveResult {
+	return runStatCurve(request, simsignals.CreateSignals())
+}
+
+/**
+ * Returns the best party assignments found for a roster, confirmed with full raid sims.
+ */
+func RaidComposition(request *proto.RaidCompositionRequest) *proto.RaidCompositionResult {
+	return runRaidComposition(request, simsignals.CreateSignals())
+}
+
+/**
+ * Sims a sequence of encounters, running down world buff timers between the pulls.
+ */
+func RaidNight(request *proto.RaidNightRequest) *proto.RaidNightResult {
+	return runRaidNight(request, simsignals.CreateSignals())
+}
+
+/**
+ * Returns the highest DPS consumable set, and the cheapest set within a tolerance of it.
+ */
+func ConsumableOptimizer(request *proto.ConsumableOptimizerRequest) *proto.ConsumableOptimizerResult {
+	return runConsumableOptimizer(request, simsignals.CreateSignals())
+}
+
+/**
+ * Sims two players fighting each other, returning win rates and the time to kill.
+ */
+func Duel(request *proto.DuelRequest) *proto.DuelResult {
+	return runDuel(request, simsignals.CreateSignals())
+}
+
+/**
+ * Explains the DPS difference of a player between two results, simming requests in place of missing results.
+ */
+func ResultDiff(request *proto.ResultDiffRequest) *proto.ResultDiffResult {
+	return runResultDiff(request, simsignals.CreateSignals())
+}
+
 // Get data for all requests needed for stat weights.
 func StatWeightRequests(request *proto.StatWeightsRequest) *proto.StatWeightRequestsData {
 	return buildStatWeightRequests(request)
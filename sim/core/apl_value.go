@@ -193,6 +193,8 @@ func (rot *APLRotation) NewAPLValue(config *proto.APLValue) APLValue {
 	// Properties
 	case *proto.APLValue_ChannelClipDelay:
 		return rot.newValueChannelClipDelay(config.GetChannelClipDelay())
+	case *proto.APLValue_UnitDistance:
+		return rot.newValueUnitDistance(config.GetUnitDistance())
 
 	default:
 		return nil
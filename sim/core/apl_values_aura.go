@@ -67,7 +67,7 @@ func (rot *APLRotation) newValueAuraIsActiveWithReactionTime(config *proto.APLVa
 	}
 	return &APLValueAuraIsActiveWithReactionTime{
 		aura:         aura,
-		reactionTime: rot.unit.ReactionTime,
+		reactionTime: rot.unit.ReactionDelay(),
 	}
 }
 func (value *APLValueAuraIsActiveWithReactionTime) Type() proto.APLValueType {
@@ -170,7 +170,7 @@ func (rot *APLRotation) newValueAuraICDIsReadyWithReactionTime(config *proto.APL
 	}
 	return &APLValueAuraICDIsReadyWithReactionTime{
 		aura:         aura,
-		reactionTime: rot.unit.ReactionTime,
+		reactionTime: rot.unit.ReactionDelay(),
 	}
 }
 func (value *APLValueAuraICDIsReadyWithReactionTime) Type() proto.APLValueType {
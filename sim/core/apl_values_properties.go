@@ -1,6 +1,7 @@
 package core
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/wowsims/sod/sim/core/proto"
@@ -40,8 +41,35 @@ func (value *APLValueFrontOfTarget) Type() proto.APLValueType {
 	return proto.APLValueType_ValueTypeBool
 }
 func (value *APLValueFrontOfTarget) GetBool(sim *Simulation) bool {
-	return value.unit.PseudoStats.InFrontOfTarget
+	return value.unit.IsInFrontOf(value.unit.CurrentTarget)
 }
 func (value *APLValueFrontOfTarget) String() string {
 	return "Front of Target()"
 }
+
+type APLValueUnitDistance struct {
+	DefaultAPLValueImpl
+	sourceUnit UnitReference
+	targetUnit UnitReference
+}
+
+func (rot *APLRotation) newValueUnitDistance(config *proto.APLValueUnitDistance) APLValue {
+	sourceUnit := rot.GetSourceUnit(config.SourceUnit)
+	targetUnit := rot.GetTargetUnit(config.TargetUnit)
+	if sourceUnit.Get() == nil || targetUnit.Get() == nil {
+		return nil
+	}
+	return &APLValueUnitDistance{
+		sourceUnit: sourceUnit,
+		targetUnit: targetUnit,
+	}
+}
+func (value *APLValueUnitDistance) Type() proto.APLValueType {
+	return proto.APLValueType_ValueTypeFloat
+}
+func (value *APLValueUnitDistance) GetFloat(sim *Simulation) float64 {
+	return value.sourceUnit.Get().RangeTo(value.targetUnit.Get())
+}
+func (value *APLValueUnitDistance) String() string {
+	return fmt.Sprintf("Distance(%s, %s)", value.sourceUnit.String(), value.targetUnit.String())
+}
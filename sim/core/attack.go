@@ -793,7 +793,8 @@ func (aa *AutoAttacks) startPull(sim *Simulation) {
 }
 
 func (wa *WeaponAttack) IsInRange() bool {
-	return (wa.MinRange == 0. || wa.MinRange <= wa.unit.DistanceFromTarget) && (wa.MaxRange == 0. || wa.MaxRange >= wa.unit.DistanceFromTarget)
+	distance := wa.unit.RangeTo(wa.unit.CurrentTarget)
+	return (wa.MinRange == 0. || wa.MinRange <= distance) && (wa.MaxRange == 0. || wa.MaxRange >= distance)
 }
 
 // Stops the auto swing action for the rest of the iteration. Used for pets
@@ -1181,7 +1181,7 @@ func (caster *Unit) NewPartyAuraArray(makeAura func(*Unit) *Aura) AuraArray {
 func (caster *Unit) NewEnemyAuraArray(makeAura func(*Unit, int32) *Aura) AuraArray {
 	auras := make([]*Aura, len(caster.Env.AllUnits))
 	for _, target := range caster.Env.AllUnits {
-		if target.Type == EnemyUnit {
+		if target.Type == EnemyUnit || caster.isDuelOpponent(target) {
 			auras[target.UnitIndex] = makeAura(target, caster.Level)
 		}
 	}
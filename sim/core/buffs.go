@@ -847,6 +847,8 @@ func applyBuffEffects(agent Agent, playerFaction proto.Faction, raidBuffs *proto
 		ApplyAshenvaleRallyingCry(&character.Unit)
 	}
 
+	applyWorldBuffTimings(&character.Unit, individualBuffs)
+
 	// TODO: Classic provide in APL?
 	registerPowerInfusionCD(agent, individualBuffs.PowerInfusions)
 	registerManaTideTotemCD(agent, partyBuffs.ManaTideTotems)
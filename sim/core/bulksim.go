@@ -93,6 +93,26 @@ func (b *bulkSimRunner) Run(signals simsignals.Signals, progress chan *proto.Pro
 	// clean to reduce memory
 	player.Database = nil
 
+	objective := b.Request.BulkSettings.Objective
+	if err := validateObjective(objective); err != nil {
+		return &proto.BulkSimResult{Error: &proto.ErrorOutcome{Message: "bulksim: " + err.Error()}}
+	}
+	if objectiveNeedsAllValues(objective) {
+		b.Request.BaseSettings.SimOptions.SaveAllValues = true
+	}
+
+	// Sims are reseeded with RandomSeed + iteration, so with a fixed seed and labeled rands
+	// iteration i of every combo sees the same random events, even with differing iteration counts.
+	paired := b.Request.BulkSettings.PairedComparison
+	if paired {
+		simOptions := b.Request.BaseSettings.SimOptions
+		if simOptions.RandomSeed == 0 {
+			simOptions.RandomSeed = time.Now().UnixNano()
+		}
+		simOptions.UseLabeledRands = true
+		simOptions.SaveAllValues = true
+	}
+
 	// Gemming for now can happen before slots are decided.
 	// We might have to add logic after slot decisions if we want to enforce keeping meta gem active.
 
@@ -222,6 +242,7 @@ func (b *bulkSimRunner) Run(signals simsignals.Signals, progress chan *proto.Pro
 	}
 
 	bum := baseResult.Result.GetRaidMetrics().GetParties()[0].GetPlayers()[0]
+	baseDpsValues := bum.GetDps().GetAllValues()
 	bum.Actions = nil
 	bum.Auras = nil
 	bum.Resources = nil
@@ -230,6 +251,7 @@ func (b *bulkSimRunner) Run(signals simsignals.Signals, progress chan *proto.Pro
 	result = &proto.BulkSimResult{
 		EquippedGearResult: &proto.BulkComboResult{
 			UnitMetrics: bum,
+			Score:       baseResult.Score(objective),
 		},
 	}
 
@@ -240,10 +262,23 @@ func (b *bulkSimRunner) Run(signals simsignals.Signals, progress chan *proto.Pro
 		um.Resources = nil
 		um.Pets = nil
 
-		result.Results = append(result.Results, &proto.BulkComboResult{
+		comboResult := &proto.BulkComboResult{
 			ItemsAdded:  r.ChangeLog.AddedItems,
 			UnitMetrics: um,
-		})
+			Score:       r.Score(objective),
+		}
+		if paired {
+			comboResult.DpsDelta, comboResult.DpsDeltaStdev = pairedDifference(um.GetDps().GetAllValues(), baseDpsValues)
+		}
+		result.Results = append(result.Results, comboResult)
+	}
+
+	// Per-iteration values are only needed for the paired differences and the objective.
+	if paired || objectiveNeedsAllValues(objective) {
+		bum.Dps.AllValues = nil
+		for _, r := range result.Results {
+			r.UnitMetrics.Dps.AllValues = nil
+		}
 	}
 
 	if progress != nil {
@@ -346,12 +381,32 @@ func (b *bulkSimRunner) getRankedResults(signals simsignals.Signals, validCombos
 	}
 	reporterSignal.Abort.Trigger() // cancel reporter
 
+	// Percentile scores sort all values, so only score each result once.
+	scores := make(map[*itemSubstitutionSimResult]float64, len(rankedResults))
+	for _, result := range rankedResults {
+		scores[result] = result.Score(b.Request.BulkSettings.Objective)
+	}
 	sort.Slice(rankedResults, func(i, j int) bool {
-		return rankedResults[i].Score() > rankedResults[j].Score()
+		return scores[rankedResults[i]] > scores[rankedResults[j]]
 	})
 	return rankedResults, baseResult, nil
 }
 
+// Returns the mean and standard deviation of a[i] - b[i]. When the iteration counts differ, e.g.
+// in fast mode, only the iterations both have in common are compared.
+func pairedDifference(a, b []float64) (float64, float64) {
+	n := min(len(a), len(b))
+	if n == 0 {
+		return 0, 0
+	}
+
+	var diff aggregator
+	for i := 0; i < n; i++ {
+		diff.add(a[i] - b[i])
+	}
+	return diff.meanAndStdDev()
+}
+
 // itemSubstitutionSimResult stores the request and response of a simulation, along with the used
 // equipment susbstitution and a changelog of which items were added and removed from the base
 // equipment set.
@@ -363,11 +418,11 @@ type itemSubstitutionSimResult struct {
 }
 
 // Score used to rank results.
-func (r *itemSubstitutionSimResult) Score() float64 {
+func (r *itemSubstitutionSimResult) Score(objective *proto.OptimizationObjective) float64 {
 	if r.Result == nil || r.Result.Error != nil {
 		return 0
 	}
-	return r.Result.RaidMetrics.Dps.Avg
+	return objectiveScore(objective, r.Result.RaidMetrics.Dps)
 }
 
 // equipmentSubstitution specifies all items to be used as replacements for the equipped gear.
@@ -380,3 +380,17 @@ func TestGenerateAllEquipmentSubstitutions(t *testing.T) {
 		})
 	}
 }
+
+func TestPairedDifference(t *testing.T) {
+	base := []float64{100, 120, 90, 110}
+	combo := []float64{102, 122, 92, 112, 500}
+
+	mean, stdev := pairedDifference(combo, base)
+	if mean != 2 || stdev != 0 {
+		t.Errorf("pairedDifference() = %f, %f, expected 2, 0", mean, stdev)
+	}
+
+	if mean, _ := pairedDifference(nil, base); mean != 0 {
+		t.Errorf("pairedDifference() with no values = %f, expected 0", mean)
+	}
+}
@@ -137,7 +137,7 @@ func (unit *Unit) applySpellPushback() {
 				}
 
 				// Update GCDTimer
-				aura.Unit.SetGCDTimer(sim, aura.Unit.Hardcast.Expires)
+				aura.Unit.SetGCDTimer(sim, aura.Unit.Hardcast.Expires+aura.Unit.castDelay)
 
 				// Update Swing timer
 				aura.Unit.AutoAttacks.StopMeleeUntil(sim, aura.Unit.Hardcast.Expires, false)
@@ -170,6 +170,10 @@ func (spell *Spell) makeCastFunc(config CastConfig) CastSuccessFunc {
 			}
 		}
 
+		if spell.Unit.isPreventedFromCasting(sim, spell) {
+			return spell.castFailureHelper(sim, "crowd controlled, silenced or locked out")
+		}
+
 		if spell.Cost != nil {
 			if !spell.Cost.MeetsRequirement(sim, spell) {
 				return spell.castFailureHelper(sim, spell.Cost.CostFailureReason(sim, spell))
@@ -226,7 +230,8 @@ func (spell *Spell) makeCastFunc(config CastConfig) CastSuccessFunc {
 			if !spell.Flags.Matches(SpellFlagChanneled) {
 				spell.SpellMetrics[target.UnitIndex].TotalCastTime += effectiveTime
 			}
-			spell.Unit.SetGCDTimer(sim, sim.CurrentTime+effectiveTime)
+			spell.Unit.castDelay = spell.Unit.nextCastDelay(sim)
+			spell.Unit.SetGCDTimer(sim, sim.CurrentTime+effectiveTime+spell.Unit.castDelay)
 		}
 
 		if (spell.CurCast.CastTime > 0) && spell.Unit.IsMoving() {
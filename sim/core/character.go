@@ -113,6 +113,7 @@ func NewCharacter(party *Party, partyIndex int, player *proto.Player) Character
 
 			ReactionTime:            max(0, time.Duration(player.ReactionTimeMs)*time.Millisecond),
 			ChannelClipDelay:        max(0, time.Duration(player.ChannelClipDelayMs)*time.Millisecond),
+			Latency:                 LatencyFromProto(player.Latency),
 			DistanceFromTarget:      player.DistanceFromTarget,
 			StartDistanceFromTarget: player.DistanceFromTarget,
 		},
@@ -136,6 +137,10 @@ func NewCharacter(party *Party, partyIndex int, player *proto.Player) Character
 	}
 
 	character.GCD = character.NewTimer()
+	if player.Position != nil {
+		position := Vector2FromProto(player.Position)
+		character.startPosition = &position
+	}
 
 	character.Label = fmt.Sprintf("%s (#%d)", character.Name, character.Index+1)
 
@@ -531,6 +536,7 @@ func (character *Character) FillPlayerStats(playerStats *proto.PlayerStats) {
 	}
 	character.clearBuildPhaseAuras(CharacterBuildPhaseAll)
 	playerStats.Sets = character.GetActiveSetBonusNames()
+	playerStats.Warnings = character.Equipment.UnimplementedEffectWarnings()
 
 	playerStats.Metadata = character.GetMetadata()
 	for _, pet := range character.Pets {
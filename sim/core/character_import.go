@@ -0,0 +1,213 @@
+package core
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+// Matches the item string part of an in-game item link, e.g. 'item:19019:1900:0:0:0:0:0:0:60'.
+// Fields are item ID, enchant ID, 4 gem IDs and the random suffix ID.
+var itemLinkRegex = regexp.MustCompile(`item:(\d+)((?::-?\d*)*)`)
+var runeRegex = regexp.MustCompile(`(?i)rune[:=](\d+)`)
+var slotPrefixRegex = regexp.MustCompile(`^\s*([A-Za-z0-9]+)\s*[:=]`)
+var settingRegex = regexp.MustCompile(`^\s*([A-Za-z]+)\s*[:=]\s*(.*?)\s*$`)
+
+// Inventory slot IDs and names used by the in-game API, see GetInventorySlotInfo().
+// Shirt (4) and tabard (19) are not tracked by the sim.
+var inventorySlots = map[string]proto.ItemSlot{
+	"1":  proto.ItemSlot_ItemSlotHead,
+	"2":  proto.ItemSlot_ItemSlotNeck,
+	"3":  proto.ItemSlot_ItemSlotShoulder,
+	"5":  proto.ItemSlot_ItemSlotChest,
+	"6":  proto.ItemSlot_ItemSlotWaist,
+	"7":  proto.ItemSlot_ItemSlotLegs,
+	"8":  proto.ItemSlot_ItemSlotFeet,
+	"9":  proto.ItemSlot_ItemSlotWrist,
+	"10": proto.ItemSlot_ItemSlotHands,
+	"11": proto.ItemSlot_ItemSlotFinger1,
+	"12": proto.ItemSlot_ItemSlotFinger2,
+	"13": proto.ItemSlot_ItemSlotTrinket1,
+	"14": proto.ItemSlot_ItemSlotTrinket2,
+	"15": proto.ItemSlot_ItemSlotBack,
+	"16": proto.ItemSlot_ItemSlotMainHand,
+	"17": proto.ItemSlot_ItemSlotOffHand,
+	"18": proto.ItemSlot_ItemSlotRanged,
+
+	"headslot":          proto.ItemSlot_ItemSlotHead,
+	"neckslot":          proto.ItemSlot_ItemSlotNeck,
+	"shoulderslot":      proto.ItemSlot_ItemSlotShoulder,
+	"backslot":          proto.ItemSlot_ItemSlotBack,
+	"chestslot":         proto.ItemSlot_ItemSlotChest,
+	"wristslot":         proto.ItemSlot_ItemSlotWrist,
+	"handsslot":         proto.ItemSlot_ItemSlotHands,
+	"waistslot":         proto.ItemSlot_ItemSlotWaist,
+	"legsslot":          proto.ItemSlot_ItemSlotLegs,
+	"feetslot":          proto.ItemSlot_ItemSlotFeet,
+	"finger0slot":       proto.ItemSlot_ItemSlotFinger1,
+	"finger1slot":       proto.ItemSlot_ItemSlotFinger2,
+	"trinket0slot":      proto.ItemSlot_ItemSlotTrinket1,
+	"trinket1slot":      proto.ItemSlot_ItemSlotTrinket2,
+	"mainhandslot":      proto.ItemSlot_ItemSlotMainHand,
+	"secondaryhandslot": proto.ItemSlot_ItemSlotOffHand,
+	"rangedslot":        proto.ItemSlot_ItemSlotRanged,
+}
+
+// Converts the text export of an in-game addon into a Player.
+// Items must be present in the sim database for their slot to be inferred, unless the
+// line is prefixed with a slot label.
+func ImportCharacter(request *proto.CharacterImportRequest) *proto.CharacterImportResult {
+	player := &proto.Player{
+		Level:     CharacterMaxLevel,
+		Equipment: &proto.EquipmentSpec{Items: make([]*proto.ItemSpec, NumItemSlots)},
+	}
+	result := &proto.CharacterImportResult{Player: player}
+	warn := func(lineNum int, format string, args ...any) {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("line %d: %s", lineNum, fmt.Sprintf(format, args...)))
+	}
+
+	for i, line := range strings.Split(request.Data, "\n") {
+		lineNum := i + 1
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		if match := itemLinkRegex.FindStringSubmatch(line); match != nil {
+			importItemLine(player.Equipment, line, match, func(format string, args ...any) { warn(lineNum, format, args...) })
+			continue
+		}
+
+		match := settingRegex.FindStringSubmatch(line)
+		if match == nil {
+			warn(lineNum, "could not parse %q", line)
+			continue
+		}
+		key, value := strings.ToLower(match[1]), match[2]
+		switch key {
+		case "name":
+			player.Name = value
+		case "race":
+			// UnitRace() returns 'Scourge' as the English name for Undead.
+			if strings.EqualFold(value, "scourge") {
+				value = "Undead"
+			}
+			race, ok := parseImportEnum(proto.Race_value, "Race", value)
+			if !ok {
+				warn(lineNum, "unknown race %q", value)
+			}
+			player.Race = proto.Race(race)
+		case "class":
+			class, ok := parseImportEnum(proto.Class_value, "Class", value)
+			if !ok {
+				warn(lineNum, "unknown class %q", value)
+			}
+			player.Class = proto.Class(class)
+		case "level":
+			level, err := strconv.Atoi(value)
+			if err != nil || level < 1 || level > CharacterMaxLevel {
+				warn(lineNum, "invalid level %q", value)
+				continue
+			}
+			player.Level = int32(level)
+		case "talents":
+			// Also accept full wowhead talent calculator URLs.
+			player.TalentsString = value[strings.LastIndex(value, "/")+1:]
+		default:
+			warn(lineNum, "unknown setting %q", match[1])
+		}
+	}
+
+	if player.Class == proto.Class_ClassUnknown {
+		result.ErrorResult = "No class found in import data"
+	}
+
+	for i, item := range player.Equipment.Items {
+		if item == nil {
+			player.Equipment.Items[i] = &proto.ItemSpec{}
+		}
+	}
+	return result
+}
+
+func importItemLine(equipment *proto.EquipmentSpec, line string, match []string, warn func(format string, args ...any)) {
+	itemID, _ := strconv.Atoi(match[1])
+	fields := strings.Split(strings.TrimPrefix(match[2], ":"), ":")
+	field := func(i int) int32 {
+		if i >= len(fields) {
+			return 0
+		}
+		value, _ := strconv.Atoi(fields[i])
+		return int32(value)
+	}
+
+	spec := &proto.ItemSpec{
+		Id:           int32(itemID),
+		Enchant:      field(0),
+		RandomSuffix: field(5),
+	}
+	if spec.RandomSuffix < 0 {
+		spec.RandomSuffix = -spec.RandomSuffix
+	}
+	if runeMatch := runeRegex.FindStringSubmatch(line); runeMatch != nil {
+		runeID, _ := strconv.Atoi(runeMatch[1])
+		spec.Rune = int32(runeID)
+	}
+
+	item, known := ItemsByID[spec.Id]
+	if !known {
+		warn("unknown item %d", spec.Id)
+	}
+	if _, ok := EnchantsByEffectID[spec.Enchant]; spec.Enchant != 0 && !ok {
+		warn("unknown enchant %d on item %d", spec.Enchant, spec.Id)
+	}
+	if _, ok := RandomSuffixesByID[spec.RandomSuffix]; spec.RandomSuffix != 0 && !ok {
+		warn("unknown random suffix %d on item %d", spec.RandomSuffix, spec.Id)
+	}
+
+	var slots []proto.ItemSlot
+	if prefix := slotPrefixRegex.FindStringSubmatch(line); prefix != nil {
+		if slot, ok := parseImportSlot(prefix[1]); ok {
+			slots = []proto.ItemSlot{slot}
+		}
+	}
+	if slots == nil && known {
+		slots = eligibleSlotsForItem(&item)
+	}
+	if slots == nil {
+		warn("cannot determine slot for item %d", spec.Id)
+		return
+	}
+
+	for _, slot := range slots {
+		if equipment.Items[slot] == nil {
+			equipment.Items[slot] = spec
+			return
+		}
+	}
+	warn("no free slot for item %d", spec.Id)
+}
+
+func parseImportSlot(label string) (proto.ItemSlot, bool) {
+	if slot, ok := inventorySlots[strings.ToLower(label)]; ok {
+		return slot, true
+	}
+	slot, ok := parseImportEnum(proto.ItemSlot_value, "ItemSlot", label)
+	return proto.ItemSlot(slot), ok
+}
+
+// Matches enum values by name, ignoring case, spaces and the enum type prefix, e.g. 'Night Elf' -> RaceNightElf.
+func parseImportEnum(values map[string]int32, prefix string, s string) (int32, bool) {
+	normalize := func(name string) string {
+		return strings.ToLower(strings.NewReplacer(" ", "", "_", "", "-", "").Replace(name))
+	}
+	target := normalize(s)
+	for name, value := range values {
+		if normalize(strings.TrimPrefix(name, prefix)) == target {
+			return value, true
+		}
+	}
+	return 0, false
+}
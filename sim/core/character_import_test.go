@@ -0,0 +1,52 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+func TestImportCharacter(t *testing.T) {
+	addToDatabase(&proto.SimDatabase{
+		Items: []*proto.SimItem{
+			{Id: 900001, Type: proto.ItemType_ItemTypeFinger},
+			{Id: 900002, Type: proto.ItemType_ItemTypeWeapon, HandType: proto.HandType_HandTypeOneHand},
+		},
+	})
+
+	result := ImportCharacter(&proto.CharacterImportRequest{Data: `
+name=Testchar
+race=Night Elf
+class=ROGUE
+level=50
+talents=https://www.wowhead.com/classic/talent-calc/rogue/005323105-3210052020050105231
+|cff0070dd|Hitem:900001::::::::60|h[Ring]|h|r
+item:900001:0:0:0:0:0:0:0:60
+item:900002:0:0:0:0:0:0:0:60 rune:400081
+SecondaryHandSlot: item:900002:0:0:0:0:0:0:0:60
+item:123456:0:0:0:0:0:0:0:60
+garbage
+`})
+
+	player := result.Player
+	if player.Race != proto.Race_RaceNightElf || player.Class != proto.Class_ClassRogue || player.Level != 50 {
+		t.Fatalf("Unexpected character settings: %v %v %d", player.Race, player.Class, player.Level)
+	}
+	if player.TalentsString != "005323105-3210052020050105231" {
+		t.Fatalf("Unexpected talents %q", player.TalentsString)
+	}
+
+	items := player.Equipment.Items
+	if items[proto.ItemSlot_ItemSlotFinger1].Id != 900001 || items[proto.ItemSlot_ItemSlotFinger2].Id != 900001 {
+		t.Fatalf("Expected both ring slots to be filled, got %v", items)
+	}
+	if items[proto.ItemSlot_ItemSlotMainHand].Id != 900002 || items[proto.ItemSlot_ItemSlotMainHand].Rune != 400081 {
+		t.Fatalf("Expected main hand with rune, got %v", items[proto.ItemSlot_ItemSlotMainHand])
+	}
+	if items[proto.ItemSlot_ItemSlotOffHand].Id != 900002 {
+		t.Fatalf("Expected labeled off hand, got %v", items[proto.ItemSlot_ItemSlotOffHand])
+	}
+	if len(result.Warnings) != 3 {
+		t.Fatalf("Expected warnings for the unknown item and the unparseable line, got %v", result.Warnings)
+	}
+}
@@ -0,0 +1,167 @@
+package core
+
+import (
+	"github.com/wowsims/sod/sim/core/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Levels from which (and up to which, if non-zero) an item of a consumable can be used.
+type consumableLevelRange struct {
+	minLevel int32
+	maxLevel int32
+}
+
+// Level requirements of the consumable items, matching the ones in ui/core/components/inputs/consumables.ts.
+// A consumable with an item per level bracket, e.g. scrolls, can be used if any of them can.
+// Consumables which aren't listed can be used at any level.
+var consumableLevelRanges = map[protoreflect.Enum][]consumableLevelRange{
+	proto.Conjured_ConjuredHealthstone:        {{24, 0}},
+	proto.Conjured_ConjuredGreaterHealthstone: {{36, 0}},
+	proto.Conjured_ConjuredMajorHealthstone:   {{48, 0}},
+	proto.Conjured_ConjuredDemonicRune:        {{40, 0}},
+	proto.Conjured_ConjuredRogueThistleTea:    {{25, 0}},
+
+	proto.EnchantedSigil_InnovationSigil:      {{40, 0}},
+	proto.EnchantedSigil_LivingDreamsSigil:    {{50, 0}},
+	proto.EnchantedSigil_FlowingWatersSigil:   {{50, 0}},
+	proto.EnchantedSigil_WrathOfTheStormSigil: {{50, 0}},
+
+	proto.SapperExplosive_SapperGoblinSapper: {{50, 0}},
+	proto.SapperExplosive_SapperFumigator:    {{60, 0}},
+
+	proto.Explosive_ExplosiveObsidianBomb:           {{60, 0}},
+	proto.Explosive_ExplosiveSolidDynamite:          {{40, 0}},
+	proto.Explosive_ExplosiveGoblinLandMine:         {{40, 0}},
+	proto.Explosive_ExplosiveDenseDynamite:          {{50, 0}},
+	proto.Explosive_ExplosiveThoriumGrenade:         {{50, 0}},
+	proto.Explosive_ExplosiveEzThroRadiationBomb:    {{40, 0}},
+	proto.Explosive_ExplosiveHighYieldRadiationBomb: {{40, 0}},
+
+	proto.Flask_FlaskOfTheTitans:             {{51, 0}},
+	proto.Flask_FlaskOfTheOldGods:            {{60, 0}},
+	proto.Flask_FlaskOfUnyieldingSorrow:      {{60, 0}},
+	proto.Flask_FlaskOfAncientKnowledge:      {{60, 0}},
+	proto.Flask_FlaskOfDistilledWisdom:       {{51, 0}},
+	proto.Flask_FlaskOfMadness:               {{60, 0}},
+	proto.Flask_FlaskOfSupremePower:          {{51, 0}},
+	proto.Flask_FlaskOfChromaticResistance:   {{51, 0}},
+	proto.Flask_FlaskOfRestlessDreams:        {{50, 59}},
+	proto.Flask_FlaskOfEverlastingNightmares: {{50, 59}},
+
+	proto.Food_FoodProwlerSteak:             {{55, 0}},
+	proto.Food_FoodFiletOFlank:              {{55, 0}},
+	proto.Food_FoodSunriseOmelette:          {{55, 0}},
+	proto.Food_FoodSpecklefinFeast:          {{55, 0}},
+	proto.Food_FoodGrandLobsterBanquet:      {{55, 0}},
+	proto.Food_FoodDarkclawBisque:           {{45, 0}},
+	proto.Food_FoodSmokedRedgill:            {{45, 0}},
+	proto.Food_FoodDirgesKickChimaerokChops: {{55, 0}},
+	proto.Food_FoodGrilledSquid:             {{50, 0}},
+	proto.Food_FoodSmokedDesertDumpling:     {{50, 0}},
+	proto.Food_FoodRunnTumTuberSurprise:     {{45, 0}},
+	proto.Food_FoodBlessSunfruit:            {{45, 0}},
+	proto.Food_FoodBlessedSunfruitJuice:     {{45, 0}},
+	proto.Food_FoodNightfinSoup:             {{35, 0}},
+	proto.Food_FoodTenderWolfSteak:          {{40, 0}},
+	proto.Food_FoodSagefishDelight:          {{30, 0}},
+	proto.Food_FoodHotWolfRibs:              {{25, 0}},
+	proto.Food_FoodSmokedSagefish:           {{10, 0}},
+
+	proto.Alcohol_AlcoholGordokGreenGrog:     {{56, 0}},
+	proto.Alcohol_AlcoholKreegsStoutBeatdown: {{56, 0}},
+
+	proto.ArmorElixir_ElixirOfTheIronside:     {{60, 0}},
+	proto.ArmorElixir_ElixirOfSuperiorDefense: {{43, 0}},
+	proto.ArmorElixir_ElixirOfGreaterDefense:  {{29, 0}},
+	proto.ArmorElixir_ElixirOfDefense:         {{16, 0}},
+	proto.ArmorElixir_ScrollOfProtection:      {{1, 14}, {15, 29}, {30, 44}, {45, 0}},
+
+	proto.HealthElixir_ElixirOfFortitude:      {{25, 0}},
+	proto.HealthElixir_ElixirOfMinorFortitude: {{2, 0}},
+
+	proto.AttackPowerBuff_JujuMight:           {{55, 0}},
+	proto.AttackPowerBuff_WinterfallFirewater: {{45, 0}},
+
+	proto.AgilityElixir_ElixirOfTheHoneyBadger: {{60, 0}},
+	proto.AgilityElixir_ElixirOfTheMongoose:    {{46, 0}},
+	proto.AgilityElixir_ElixirOfGreaterAgility: {{38, 0}},
+	proto.AgilityElixir_ElixirOfAgility:        {{27, 0}},
+	proto.AgilityElixir_ElixirOfLesserAgility:  {{18, 0}},
+	proto.AgilityElixir_ScrollOfAgility:        {{10, 24}, {25, 39}, {40, 54}, {55, 0}},
+
+	proto.StrengthBuff_JujuPower:             {{55, 0}},
+	proto.StrengthBuff_ElixirOfGiants:        {{46, 0}},
+	proto.StrengthBuff_ElixirOfOgresStrength: {{20, 0}},
+	proto.StrengthBuff_ScrollOfStrength:      {{10, 24}, {25, 39}, {40, 54}, {55, 0}},
+
+	proto.ZanzaBuff_ROIDS:                  {{45, 0}},
+	proto.ZanzaBuff_GroundScorpokAssay:     {{45, 0}},
+	proto.ZanzaBuff_LungJuiceCocktail:      {{45, 0}},
+	proto.ZanzaBuff_CerebralCortexCompound: {{45, 0}},
+	proto.ZanzaBuff_GizzardGum:             {{45, 0}},
+	proto.ZanzaBuff_SpiritOfZanza:          {{55, 0}},
+
+	proto.Potions_GreaterHealingPotion:     {{21, 0}},
+	proto.Potions_SuperiorHealingPotion:    {{35, 0}},
+	proto.Potions_MajorHealingPotion:       {{45, 0}},
+	proto.Potions_ManaPotion:               {{22, 0}},
+	proto.Potions_GreaterManaPotion:        {{31, 0}},
+	proto.Potions_SuperiorManaPotion:       {{41, 0}},
+	proto.Potions_MajorManaPotion:          {{49, 0}},
+	proto.Potions_MightyRagePotion:         {{46, 0}},
+	proto.Potions_GreatRagePotion:          {{25, 0}},
+	proto.Potions_RagePotion:               {{4, 0}},
+	proto.Potions_MagicResistancePotion:    {{32, 0}},
+	proto.Potions_GreaterStoneshieldPotion: {{46, 0}},
+	proto.Potions_LesserStoneshieldPotion:  {{33, 0}},
+
+	proto.SpellPowerBuff_ElixirOfTheMageLord: {{60, 0}},
+	proto.SpellPowerBuff_GreaterArcaneElixir: {{46, 0}},
+	proto.SpellPowerBuff_ArcaneElixir:        {{37, 0}},
+	proto.SpellPowerBuff_LesserArcaneElixir:  {{28, 0}},
+
+	proto.FirePowerBuff_ElixirOfGreaterFirepower: {{51, 0}},
+	proto.FirePowerBuff_ElixirOfFirepower:        {{18, 0}},
+
+	proto.FrostPowerBuff_ElixirOfFrostPower: {{40, 0}},
+
+	proto.ShadowPowerBuff_ElixirOfShadowPower: {{40, 0}},
+
+	proto.ManaRegenElixir_MagebloodPotion: {{40, 0}},
+
+	proto.WeaponImbue_BlessedWizardOil:                   {{50, 0}},
+	proto.WeaponImbue_EnchantedRepellent:                 {{60, 0}},
+	proto.WeaponImbue_BrilliantWizardOil:                 {{45, 0}},
+	proto.WeaponImbue_WizardOil:                          {{40, 0}},
+	proto.WeaponImbue_LesserWizardOil:                    {{30, 0}},
+	proto.WeaponImbue_MinorWizardOil:                     {{5, 0}},
+	proto.WeaponImbue_BrilliantManaOil:                   {{45, 0}},
+	proto.WeaponImbue_LesserManaOil:                      {{40, 0}},
+	proto.WeaponImbue_MinorManaOil:                       {{20, 0}},
+	proto.WeaponImbue_BlackfathomManaOil:                 {{25, 0}},
+	proto.WeaponImbue_ConsecratedSharpeningStone:         {{50, 0}},
+	proto.WeaponImbue_WeightedConsecratedSharpeningStone: {{50, 0}},
+	proto.WeaponImbue_ElementalSharpeningStone:           {{50, 0}},
+	proto.WeaponImbue_DenseSharpeningStone:               {{35, 0}},
+	proto.WeaponImbue_SolidSharpeningStone:               {{35, 0}},
+	proto.WeaponImbue_DenseWeightstone:                   {{35, 0}},
+	proto.WeaponImbue_SolidWeightstone:                   {{35, 0}},
+	proto.WeaponImbue_ShadowOil:                          {{25, 0}},
+	proto.WeaponImbue_FrostOil:                           {{40, 0}},
+	proto.WeaponImbue_ConductiveShieldCoating:            {{40, 0}},
+	proto.WeaponImbue_MagnificentTrollshine:              {{45, 0}},
+}
+
+// Whether the player's level allows using the consumable.
+func isConsumableUsableAtLevel(option protoreflect.Enum, level int32) bool {
+	ranges, ok := consumableLevelRanges[option]
+	if !ok {
+		return true
+	}
+	for _, r := range ranges {
+		if level >= r.minLevel && (r.maxLevel == 0 || level <= r.maxLevel) {
+			return true
+		}
+	}
+	return false
+}
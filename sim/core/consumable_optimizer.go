@@ -0,0 +1,389 @@
+package core
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/wowsims/sod/sim/core/proto"
+	"github.com/wowsims/sod/sim/core/simsignals"
+	googleProto "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+const (
+	defaultConsumableSearchIterations = 1000
+	defaultConsumableBudgetTolerance  = 0.02
+	consumableSearchMaxPasses         = 3
+)
+
+// Imbues which can only be used by one class, and ones which come from other raid members rather than consumables.
+var (
+	classWeaponImbues = map[proto.WeaponImbue]proto.Class{
+		proto.WeaponImbue_RockbiterWeapon:   proto.Class_ClassShaman,
+		proto.WeaponImbue_FlametongueWeapon: proto.Class_ClassShaman,
+		proto.WeaponImbue_FrostbrandWeapon:  proto.Class_ClassShaman,
+		proto.WeaponImbue_WindfuryWeapon:    proto.Class_ClassShaman,
+		proto.WeaponImbue_InstantPoison:     proto.Class_ClassRogue,
+		proto.WeaponImbue_DeadlyPoison:      proto.Class_ClassRogue,
+		proto.WeaponImbue_WoundPoison:       proto.Class_ClassRogue,
+		proto.WeaponImbue_OccultPoison:      proto.Class_ClassRogue,
+		proto.WeaponImbue_SebaciousPoison:   proto.Class_ClassRogue,
+		proto.WeaponImbue_AtrophicPoison:    proto.Class_ClassRogue,
+		proto.WeaponImbue_NumbingPoison:     proto.Class_ClassRogue,
+	}
+	raidWeaponImbues   = []proto.WeaponImbue{proto.WeaponImbue_WildStrikes, proto.WeaponImbue_Windfury}
+	shieldWeaponImbues = []proto.WeaponImbue{proto.WeaponImbue_ConductiveShieldCoating, proto.WeaponImbue_MagnificentTrollshine}
+)
+
+// One enum field of Consumes, and the choices the player can use in it.
+type consumableSlot struct {
+	field   protoreflect.FieldDescriptor
+	options []protoreflect.EnumNumber
+}
+
+type consumableOptimizer struct {
+	request     *proto.ConsumableOptimizerRequest
+	baseRequest *proto.RaidSimRequest
+	slots       []consumableSlot
+	prices      map[protoreflect.Name]map[protoreflect.Name]float64
+	signals     simsignals.Signals
+
+	searchIterations int32
+	searchDps        map[string]float64
+
+	runSim func(*proto.RaidSimRequest, chan *proto.ProgressMetrics, simsignals.Signals) *proto.RaidSimResult
+}
+
+// Runs a coordinate search over the consumable slots for the highest DPS, then drops or downgrades
+// consumables while the DPS stays within the budget tolerance, saving the most gold per DPS lost first.
+// All sims share a seed, so differences between sets come from the consumables rather than RNG.
+func runConsumableOptimizer(request *proto.ConsumableOptimizerRequest, signals simsignals.Signals) *proto.ConsumableOptimizerResult {
+	if request.Player == nil {
+		return &proto.ConsumableOptimizerResult{Error: &proto.ErrorOutcome{Message: "No player"}}
+	}
+	tolerance := request.BudgetTolerance
+	if tolerance == 0 {
+		tolerance = defaultConsumableBudgetTolerance
+	}
+	if tolerance < 0 || tolerance >= 1 {
+		return &proto.ConsumableOptimizerResult{Error: &proto.ErrorOutcome{Message: "budget_tolerance must be between 0 and 1"}}
+	}
+
+	optimizer := &consumableOptimizer{
+		request:          request,
+		signals:          signals,
+		searchIterations: request.SearchIterations,
+		searchDps:        make(map[string]float64),
+	}
+	if optimizer.searchIterations == 0 {
+		optimizer.searchIterations = defaultConsumableSearchIterations
+	}
+	if err := optimizer.parsePrices(); err != nil {
+		return &proto.ConsumableOptimizerResult{Error: &proto.ErrorOutcome{Message: err.Error()}}
+	}
+	optimizer.slots = consumableSlots(request.Player)
+
+	player := googleProto.Clone(request.Player).(*proto.Player)
+	if player.Consumes == nil {
+		player.Consumes = &proto.Consumes{}
+	}
+	raidProto := SinglePlayerRaidProto(player, request.PartyBuffs, request.RaidBuffs, request.Debuffs)
+	raidProto.Tanks = request.Tanks
+
+	simOptions := &proto.SimOptions{}
+	if request.SimOptions != nil {
+		simOptions = googleProto.Clone(request.SimOptions).(*proto.SimOptions)
+	}
+	simOptions.UseLabeledRands = true
+	if simOptions.RandomSeed == 0 {
+		simOptions.RandomSeed = time.Now().UnixNano()
+	}
+	optimizer.baseRequest = &proto.RaidSimRequest{
+		Raid:       raidProto,
+		Encounter:  request.Encounter,
+		SimOptions: simOptions,
+	}
+	// Don't use go threads in wasm, it just adds more overhead and makes the worker more unresponsive.
+	optimizer.runSim = runSimConcurrent
+	if IsRunningInWasm() || simOptions.IsTest {
+		optimizer.runSim = RunSim
+	}
+
+	base := googleProto.Clone(player.Consumes).(*proto.Consumes)
+	for _, slot := range optimizer.slots {
+		base.ProtoReflect().Clear(slot.field)
+	}
+
+	best, err := optimizer.search(base)
+	if err != nil {
+		return &proto.ConsumableOptimizerResult{Error: &proto.ErrorOutcome{Message: err.Error()}}
+	}
+	budget, err := optimizer.budget(best, tolerance)
+	if err != nil {
+		return &proto.ConsumableOptimizerResult{Error: &proto.ErrorOutcome{Message: err.Error()}}
+	}
+
+	baseResult, err := optimizer.confirm(base)
+	if err != nil {
+		return &proto.ConsumableOptimizerResult{Error: &proto.ErrorOutcome{Message: err.Error()}}
+	}
+	result := &proto.ConsumableOptimizerResult{BaseDps: baseResult.Avg}
+	if result.Best, err = optimizer.consumableSet(best, result.BaseDps); err != nil {
+		return &proto.ConsumableOptimizerResult{Error: &proto.ErrorOutcome{Message: err.Error()}}
+	}
+	if result.Budget, err = optimizer.consumableSet(budget, result.BaseDps); err != nil {
+		return &proto.ConsumableOptimizerResult{Error: &proto.ErrorOutcome{Message: err.Error()}}
+	}
+	return result
+}
+
+// The enum fields of Consumes, with the choices which are legal for the player's class, level and weapons.
+func consumableSlots(player *proto.Player) []consumableSlot {
+	hasOffHand := false
+	if items := player.GetEquipment().GetItems(); len(items) > int(proto.ItemSlot_ItemSlotOffHand) {
+		hasOffHand = items[proto.ItemSlot_ItemSlotOffHand].GetId() != 0
+	}
+
+	var slots []consumableSlot
+	fields := (&proto.Consumes{}).ProtoReflect().Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		if field.Kind() != protoreflect.EnumKind || field.IsList() {
+			continue
+		}
+		isImbue := field.Name() == "main_hand_imbue" || field.Name() == "off_hand_imbue"
+		if field.Name() == "off_hand_imbue" && !hasOffHand {
+			continue
+		}
+
+		enumType, err := protoregistry.GlobalTypes.FindEnumByName(field.Enum().FullName())
+		if err != nil {
+			panic(err)
+		}
+
+		slot := consumableSlot{field: field}
+		values := field.Enum().Values()
+		for j := 0; j < values.Len(); j++ {
+			option := values.Get(j).Number()
+			if option == 0 || !isConsumableUsableAtLevel(enumType.New(option), player.Level) {
+				continue
+			}
+			if isImbue {
+				imbue := proto.WeaponImbue(option)
+				if class, ok := classWeaponImbues[imbue]; ok && class != player.Class {
+					continue
+				}
+				if slices.Contains(raidWeaponImbues, imbue) {
+					continue
+				}
+				if slices.Contains(shieldWeaponImbues, imbue) && field.Name() == "main_hand_imbue" {
+					continue
+				}
+			}
+			slot.options = append(slot.options, option)
+		}
+		slots = append(slots, slot)
+	}
+	return slots
+}
+
+func (optimizer *consumableOptimizer) parsePrices() error {
+	fields := (&proto.Consumes{}).ProtoReflect().Descriptor().Fields()
+	optimizer.prices = make(map[protoreflect.Name]map[protoreflect.Name]float64)
+	for _, price := range optimizer.request.Prices {
+		field := fields.ByName(protoreflect.Name(price.Consume))
+		if field == nil || field.Kind() != protoreflect.EnumKind {
+			return fmt.Errorf("price for unknown consumable slot %q", price.Consume)
+		}
+		if field.Enum().Values().ByName(protoreflect.Name(price.Option)) == nil {
+			return fmt.Errorf("price for unknown %s option %q", price.Consume, price.Option)
+		}
+		if price.Gold < 0 {
+			return fmt.Errorf("price for %s %s can't be negative", price.Consume, price.Option)
+		}
+		if optimizer.prices[field.Name()] == nil {
+			optimizer.prices[field.Name()] = make(map[protoreflect.Name]float64)
+		}
+		optimizer.prices[field.Name()][protoreflect.Name(price.Option)] = price.Gold
+	}
+	return nil
+}
+
+func (optimizer *consumableOptimizer) price(field protoreflect.FieldDescriptor, option protoreflect.EnumNumber) float64 {
+	if option == 0 {
+		return 0
+	}
+	return optimizer.prices[field.Name()][field.Enum().Values().ByNumber(option).Name()]
+}
+
+func (optimizer *consumableOptimizer) gold(consumes *proto.Consumes) float64 {
+	gold := 0.0
+	for _, slot := range optimizer.slots {
+		gold += optimizer.price(slot.field, consumes.ProtoReflect().Get(slot.field).Enum())
+	}
+	return gold
+}
+
+func withConsumable(consumes *proto.Consumes, field protoreflect.FieldDescriptor, option protoreflect.EnumNumber) *proto.Consumes {
+	consumes = googleProto.Clone(consumes).(*proto.Consumes)
+	consumes.ProtoReflect().Set(field, protoreflect.ValueOfEnum(option))
+	return consumes
+}
+
+func (optimizer *consumableOptimizer) sim(consumes *proto.Consumes, iterations int32) (*proto.DistributionMetrics, error) {
+	request := googleProto.Clone(optimizer.baseRequest).(*proto.RaidSimRequest)
+	request.Raid.Parties[0].Players[0].Consumes = consumes
+	if iterations > 0 {
+		request.SimOptions.Iterations = iterations
+		request.SimOptions.TargetRelativeError = 0
+	}
+
+	result := optimizer.runSim(request, nil, optimizer.signals)
+	if result.Error != nil {
+		return nil, fmt.Errorf("%s", result.Error.Message)
+	}
+	return result.RaidMetrics.Parties[0].Players[0].Dps, nil
+}
+
+// DPS of a set with the search iterations. Sets are often revisited, so results are cached.
+func (optimizer *consumableOptimizer) dps(consumes *proto.Consumes) (float64, error) {
+	key, err := googleProto.MarshalOptions{Deterministic: true}.Marshal(consumes)
+	if err != nil {
+		return 0, err
+	}
+	if dps, ok := optimizer.searchDps[string(key)]; ok {
+		return dps, nil
+	}
+
+	dps, err := optimizer.sim(consumes, optimizer.searchIterations)
+	if err != nil {
+		return 0, err
+	}
+	optimizer.searchDps[string(key)] = dps.Avg
+	return dps.Avg, nil
+}
+
+func (optimizer *consumableOptimizer) confirm(consumes *proto.Consumes) (*proto.DistributionMetrics, error) {
+	return optimizer.sim(consumes, 0)
+}
+
+// Picks the best option of one slot at a time, holding the others fixed, until a pass changes nothing.
+func (optimizer *consumableOptimizer) search(consumes *proto.Consumes) (*proto.Consumes, error) {
+	currentDps, err := optimizer.dps(consumes)
+	if err != nil {
+		return nil, err
+	}
+
+	for pass := 0; pass < consumableSearchMaxPasses; pass++ {
+		improved := false
+		for _, slot := range optimizer.slots {
+			current := consumes.ProtoReflect().Get(slot.field).Enum()
+			for _, option := range append([]protoreflect.EnumNumber{0}, slot.options...) {
+				if option == current {
+					continue
+				}
+				candidate := withConsumable(consumes, slot.field, option)
+				dps, err := optimizer.dps(candidate)
+				if err != nil {
+					return nil, err
+				}
+				if dps > currentDps {
+					consumes, currentDps, current = candidate, dps, option
+					improved = true
+				}
+			}
+		}
+		if !improved {
+			break
+		}
+	}
+	return consumes, nil
+}
+
+// Repeatedly takes the cheaper option of a slot which saves the most gold per DPS lost, as long as
+// the DPS stays within the tolerance of the best set.
+func (optimizer *consumableOptimizer) budget(best *proto.Consumes, tolerance float64) (*proto.Consumes, error) {
+	bestDps, err := optimizer.dps(best)
+	if err != nil {
+		return nil, err
+	}
+	minDps := bestDps * (1 - tolerance)
+
+	consumes, currentDps := best, bestDps
+	for {
+		var move *proto.Consumes
+		moveDps, moveScore := 0.0, 0.0
+		for _, slot := range optimizer.slots {
+			currentGold := optimizer.price(slot.field, consumes.ProtoReflect().Get(slot.field).Enum())
+			for _, option := range append([]protoreflect.EnumNumber{0}, slot.options...) {
+				saved := currentGold - optimizer.price(slot.field, option)
+				if saved <= 0 {
+					continue
+				}
+				candidate := withConsumable(consumes, slot.field, option)
+				dps, err := optimizer.dps(candidate)
+				if err != nil {
+					return nil, err
+				}
+				if dps < minDps {
+					continue
+				}
+				score := saved / math.Max(currentDps-dps, 1e-9)
+				if move == nil || score > moveScore {
+					move, moveDps, moveScore = candidate, dps, score
+				}
+			}
+		}
+		if move == nil {
+			return consumes, nil
+		}
+		consumes, currentDps = move, moveDps
+	}
+}
+
+func (optimizer *consumableOptimizer) consumableSet(consumes *proto.Consumes, baseDps float64) (*proto.ConsumableSet, error) {
+	dps, err := optimizer.confirm(consumes)
+	if err != nil {
+		return nil, err
+	}
+	searchDps, err := optimizer.dps(consumes)
+	if err != nil {
+		return nil, err
+	}
+
+	set := &proto.ConsumableSet{
+		Consumes: consumes,
+		Dps:      dps.Avg,
+		DpsStdev: dps.Stdev,
+		DpsGain:  dps.Avg - baseDps,
+		Gold:     optimizer.gold(consumes),
+	}
+	if set.Gold > 0 {
+		set.DpsGainPerGold = set.DpsGain / set.Gold
+	}
+
+	for _, slot := range optimizer.slots {
+		option := consumes.ProtoReflect().Get(slot.field).Enum()
+		if option == 0 {
+			continue
+		}
+		withoutDps, err := optimizer.dps(withConsumable(consumes, slot.field, 0))
+		if err != nil {
+			return nil, err
+		}
+		choice := &proto.ConsumableChoice{
+			Consume: string(slot.field.Name()),
+			Option:  string(slot.field.Enum().Values().ByNumber(option).Name()),
+			DpsGain: searchDps - withoutDps,
+			Gold:    optimizer.price(slot.field, option),
+		}
+		if choice.Gold > 0 {
+			choice.DpsGainPerGold = choice.DpsGain / choice.Gold
+		}
+		set.Choices = append(set.Choices, choice)
+	}
+	return set, nil
+}
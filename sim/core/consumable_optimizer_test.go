@@ -0,0 +1,160 @@
+package core
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/wowsims/sod/sim/core/proto"
+	"github.com/wowsims/sod/sim/core/simsignals"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func imbueOptions(slots []consumableSlot, name protoreflect.Name) []proto.WeaponImbue {
+	for _, slot := range slots {
+		if slot.field.Name() == name {
+			return MapSlice(slot.options, func(option protoreflect.EnumNumber) proto.WeaponImbue { return proto.WeaponImbue(option) })
+		}
+	}
+	return nil
+}
+
+func TestConsumableSlots(t *testing.T) {
+	items := make([]*proto.ItemSpec, proto.ItemSlot_ItemSlotOffHand+1)
+	items[proto.ItemSlot_ItemSlotOffHand] = &proto.ItemSpec{Id: 1}
+	rogue := &proto.Player{Class: proto.Class_ClassRogue, Level: 60, Equipment: &proto.EquipmentSpec{Items: items}}
+
+	slots := consumableSlots(rogue)
+	mainHand := imbueOptions(slots, "main_hand_imbue")
+	offHand := imbueOptions(slots, "off_hand_imbue")
+	if !slices.Contains(mainHand, proto.WeaponImbue_InstantPoison) || slices.Contains(mainHand, proto.WeaponImbue_WindfuryWeapon) {
+		t.Fatalf("Expected rogue but no shaman imbues, got %v", mainHand)
+	}
+	if slices.Contains(mainHand, proto.WeaponImbue_Windfury) || slices.Contains(mainHand, proto.WeaponImbue_ConductiveShieldCoating) {
+		t.Fatalf("Expected no raid or shield imbues on the main hand, got %v", mainHand)
+	}
+	if !slices.Contains(offHand, proto.WeaponImbue_ConductiveShieldCoating) {
+		t.Fatalf("Expected shield imbues on the off hand, got %v", offHand)
+	}
+
+	if offHand := imbueOptions(consumableSlots(&proto.Player{Class: proto.Class_ClassWarrior, Level: 60}), "off_hand_imbue"); offHand != nil {
+		t.Fatalf("Expected no off hand imbues without an off hand, got %v", offHand)
+	}
+}
+
+func flaskOptions(slots []consumableSlot) []proto.Flask {
+	for _, slot := range slots {
+		if slot.field.Name() == "flask" {
+			return MapSlice(slot.options, func(option protoreflect.EnumNumber) proto.Flask { return proto.Flask(option) })
+		}
+	}
+	return nil
+}
+
+func TestConsumableSlotsByLevel(t *testing.T) {
+	for _, test := range []struct {
+		level    int32
+		included []proto.Flask
+		excluded []proto.Flask
+	}{
+		{25, nil, []proto.Flask{proto.Flask_FlaskOfRestlessDreams, proto.Flask_FlaskOfSupremePower, proto.Flask_FlaskOfMadness}},
+		{50, []proto.Flask{proto.Flask_FlaskOfRestlessDreams}, []proto.Flask{proto.Flask_FlaskOfSupremePower, proto.Flask_FlaskOfMadness}},
+		{60, []proto.Flask{proto.Flask_FlaskOfSupremePower, proto.Flask_FlaskOfMadness}, []proto.Flask{proto.Flask_FlaskOfRestlessDreams}},
+	} {
+		flasks := flaskOptions(consumableSlots(&proto.Player{Class: proto.Class_ClassMage, Level: test.level}))
+		for _, flask := range test.included {
+			if !slices.Contains(flasks, flask) {
+				t.Fatalf("Expected %s at level %d, got %v", flask, test.level, flasks)
+			}
+		}
+		for _, flask := range test.excluded {
+			if slices.Contains(flasks, flask) {
+				t.Fatalf("Expected no %s at level %d, got %v", flask, test.level, flasks)
+			}
+		}
+	}
+
+	// Scrolls have an item per level bracket.
+	if !isConsumableUsableAtLevel(proto.AgilityElixir_ScrollOfAgility, 25) || isConsumableUsableAtLevel(proto.AgilityElixir_ScrollOfAgility, 5) {
+		t.Fatalf("Expected scrolls of agility from level 10")
+	}
+}
+
+// An optimizer over flasks and food, whose sims return a fixed DPS per consumable.
+func newTestConsumableOptimizer(t *testing.T, prices []*proto.ConsumablePrice) *consumableOptimizer {
+	fields := (&proto.Consumes{}).ProtoReflect().Descriptor().Fields()
+	optimizer := &consumableOptimizer{
+		request: &proto.ConsumableOptimizerRequest{Prices: prices},
+		slots: []consumableSlot{
+			{field: fields.ByName("flask"), options: []protoreflect.EnumNumber{protoreflect.EnumNumber(proto.Flask_FlaskOfTheTitans), protoreflect.EnumNumber(proto.Flask_FlaskOfSupremePower)}},
+			{field: fields.ByName("food"), options: []protoreflect.EnumNumber{protoreflect.EnumNumber(proto.Food_FoodNightfinSoup)}},
+		},
+		baseRequest:      &proto.RaidSimRequest{Raid: SinglePlayerRaidProto(&proto.Player{}, nil, nil, nil), SimOptions: &proto.SimOptions{}},
+		searchIterations: 100,
+		searchDps:        make(map[string]float64),
+	}
+	optimizer.runSim = func(request *proto.RaidSimRequest, _ chan *proto.ProgressMetrics, _ simsignals.Signals) *proto.RaidSimResult {
+		consumes := request.Raid.Parties[0].Players[0].Consumes
+		dps := 1000.0 + map[proto.Flask]float64{proto.Flask_FlaskOfTheTitans: 95, proto.Flask_FlaskOfSupremePower: 100}[consumes.Flask]
+		if consumes.Food == proto.Food_FoodNightfinSoup {
+			dps += 10
+		}
+		return &proto.RaidSimResult{RaidMetrics: &proto.RaidMetrics{Parties: []*proto.PartyMetrics{{Players: []*proto.UnitMetrics{{Dps: &proto.DistributionMetrics{Avg: dps}}}}}}}
+	}
+	if err := optimizer.parsePrices(); err != nil {
+		t.Fatalf("Failed to parse prices: %s", err)
+	}
+	return optimizer
+}
+
+func TestConsumableOptimizerSearchAndBudget(t *testing.T) {
+	optimizer := newTestConsumableOptimizer(t, []*proto.ConsumablePrice{
+		{Consume: "flask", Option: "FlaskOfSupremePower", Gold: 50},
+		{Consume: "flask", Option: "FlaskOfTheTitans", Gold: 5},
+		{Consume: "food", Option: "FoodNightfinSoup", Gold: 10},
+	})
+
+	best, err := optimizer.search(&proto.Consumes{})
+	if err != nil {
+		t.Fatalf("Search failed: %s", err)
+	}
+	if best.Flask != proto.Flask_FlaskOfSupremePower || best.Food != proto.Food_FoodNightfinSoup {
+		t.Fatalf("Expected the highest DPS flask and food, got %v", best)
+	}
+
+	// Within 2% of 1110 DPS, the cheaper flask and then no food lose 5 and 10 DPS for 45 and 10 gold.
+	budget, err := optimizer.budget(best, 0.02)
+	if err != nil {
+		t.Fatalf("Budget failed: %s", err)
+	}
+	if budget.Flask != proto.Flask_FlaskOfTheTitans || budget.Food != proto.Food_FoodUnknown {
+		t.Fatalf("Expected only the cheaper flask, got %v", budget)
+	}
+
+	// With a tighter tolerance only the flask is downgraded.
+	if budget, _ := optimizer.budget(best, 0.005); budget.Flask != proto.Flask_FlaskOfTheTitans || budget.Food != proto.Food_FoodNightfinSoup {
+		t.Fatalf("Expected the cheaper flask with food, got %v", budget)
+	}
+}
+
+func TestConsumableSet(t *testing.T) {
+	optimizer := newTestConsumableOptimizer(t, []*proto.ConsumablePrice{
+		{Consume: "flask", Option: "FlaskOfSupremePower", Gold: 50},
+	})
+
+	set, err := optimizer.consumableSet(&proto.Consumes{Flask: proto.Flask_FlaskOfSupremePower, Food: proto.Food_FoodNightfinSoup}, 1000)
+	if err != nil {
+		t.Fatalf("Failed to build the set: %s", err)
+	}
+	if set.Dps != 1110 || set.DpsGain != 110 || set.Gold != 50 || !WithinToleranceFloat64(set.DpsGainPerGold, 2.2, 1e-9) {
+		t.Fatalf("Expected 110 DPS gain for 50 gold, got %v", set)
+	}
+	if len(set.Choices) != 2 {
+		t.Fatalf("Expected a choice per consumable, got %v", set.Choices)
+	}
+	if flask := set.Choices[0]; flask.Option != "FlaskOfSupremePower" || flask.DpsGain != 100 || flask.DpsGainPerGold != 2 {
+		t.Fatalf("Expected the flask to gain 2 DPS per gold, got %v", flask)
+	}
+	if food := set.Choices[1]; food.Option != "FoodNightfinSoup" || food.DpsGain != 10 || food.Gold != 0 || food.DpsGainPerGold != 0 {
+		t.Fatalf("Expected unpriced food to have no DPS per gold, got %v", food)
+	}
+}
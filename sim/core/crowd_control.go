@@ -0,0 +1,248 @@
+package core
+
+import (
+	"time"
+
+	"github.com/wowsims/sod/sim/core/stats"
+)
+
+// Kinds of loss of control effects. Effects of the same category share diminishing returns.
+type CrowdControlCategory int32
+
+const (
+	CrowdControlStun CrowdControlCategory = iota
+	CrowdControlFear
+	CrowdControlIncapacitate
+	CrowdControlSilence
+
+	numCrowdControlCategories
+)
+
+var crowdControlLabels = [numCrowdControlCategories]string{
+	CrowdControlStun:         "Stunned",
+	CrowdControlFear:         "Feared",
+	CrowdControlIncapacitate: "Incapacitated",
+	CrowdControlSilence:      "Silenced",
+}
+
+// Diminishing returns of a category reset this long after its last application.
+const diminishingReturnsResetTime = time.Second * 15
+
+// Duration multipliers for the first, second and third application of a category within the
+// reset window. The unit is immune to further applications.
+var diminishingReturnsMultipliers = [...]float64{1, 0.5, 0.25}
+
+// Fears break once the unit took this share of its maximum health in damage.
+const fearBreakHealthFraction = 0.15
+
+type crowdControl struct {
+	auras     [numCrowdControlCategories]*Aura
+	drCount   [numCrowdControlCategories]int
+	drResetAt [numCrowdControlCategories]time.Duration
+
+	fearDamage     float64
+	schoolLockouts [stats.SchoolLen]time.Duration
+}
+
+// Allows the unit to be crowd controlled and locked out by interrupts. Only duelists are, so
+// NPC targets stay immune as they are in raids.
+func (unit *Unit) enableCrowdControl() {
+	cc := &crowdControl{}
+	unit.crowdControl = cc
+
+	loseControl := func(aura *Aura, sim *Simulation) {
+		unit.InterruptCast(sim, 0)
+		unit.AutoAttacks.CancelAutoSwing(sim)
+	}
+	regainControl := func(aura *Aura, sim *Simulation) {
+		if cc.isControlled() || unit.IsMoving() {
+			return
+		}
+		unit.AutoAttacks.EnableAutoSwing(sim)
+	}
+
+	cc.auras[CrowdControlStun] = unit.RegisterAura(Aura{
+		Label:    crowdControlLabels[CrowdControlStun],
+		Duration: time.Second,
+		OnGain: func(aura *Aura, sim *Simulation) {
+			unit.PseudoStats.Stunned = true
+			loseControl(aura, sim)
+		},
+		OnExpire: func(aura *Aura, sim *Simulation) {
+			unit.PseudoStats.Stunned = false
+			regainControl(aura, sim)
+		},
+	})
+
+	cc.auras[CrowdControlFear] = unit.RegisterAura(Aura{
+		Label:    crowdControlLabels[CrowdControlFear],
+		Duration: time.Second,
+		OnGain: func(aura *Aura, sim *Simulation) {
+			cc.fearDamage = 0
+			loseControl(aura, sim)
+		},
+		OnExpire: regainControl,
+		OnSpellHitTaken: func(aura *Aura, sim *Simulation, spell *Spell, result *SpellResult) {
+			cc.breakFearOnDamage(aura, sim, result)
+		},
+		OnPeriodicDamageTaken: func(aura *Aura, sim *Simulation, spell *Spell, result *SpellResult) {
+			cc.breakFearOnDamage(aura, sim, result)
+		},
+	})
+
+	cc.auras[CrowdControlIncapacitate] = unit.RegisterAura(Aura{
+		Label:    crowdControlLabels[CrowdControlIncapacitate],
+		Duration: time.Second,
+		OnGain:   loseControl,
+		OnExpire: regainControl,
+		OnSpellHitTaken: func(aura *Aura, sim *Simulation, spell *Spell, result *SpellResult) {
+			if result.Damage > 0 {
+				aura.Deactivate(sim)
+			}
+		},
+		OnPeriodicDamageTaken: func(aura *Aura, sim *Simulation, spell *Spell, result *SpellResult) {
+			if result.Damage > 0 {
+				aura.Deactivate(sim)
+			}
+		},
+	})
+
+	cc.auras[CrowdControlSilence] = unit.RegisterAura(Aura{
+		Label:    crowdControlLabels[CrowdControlSilence],
+		Duration: time.Second,
+		OnGain: func(aura *Aura, sim *Simulation) {
+			if unit.IsChanneling(sim) && isSilenceable(unit.ChanneledDot.Spell) {
+				unit.InterruptCast(sim, 0)
+			} else if spell := unit.GetSpell(unit.Hardcast.ActionID); unit.IsCasting(sim) && spell != nil && isSilenceable(spell) {
+				unit.InterruptCast(sim, 0)
+			}
+		},
+	})
+
+	unit.RegisterResetEffect(func(sim *Simulation) {
+		cc.drCount = [numCrowdControlCategories]int{}
+		cc.drResetAt = [numCrowdControlCategories]time.Duration{}
+		cc.schoolLockouts = [stats.SchoolLen]time.Duration{}
+		cc.fearDamage = 0
+	})
+}
+
+func (cc *crowdControl) breakFearOnDamage(aura *Aura, sim *Simulation, result *SpellResult) {
+	cc.fearDamage += result.Damage
+	if cc.fearDamage >= aura.Unit.MaxHealth()*fearBreakHealthFraction {
+		aura.Deactivate(sim)
+	}
+}
+
+// Counts an application of the category at the given time, and returns its duration after
+// diminishing returns.
+func (cc *crowdControl) diminishDuration(now time.Duration, category CrowdControlCategory, duration time.Duration) time.Duration {
+	if now >= cc.drResetAt[category] {
+		cc.drCount[category] = 0
+	}
+	if cc.drCount[category] >= len(diminishingReturnsMultipliers) {
+		return 0
+	}
+
+	duration = time.Duration(float64(duration) * diminishingReturnsMultipliers[cc.drCount[category]])
+	cc.drCount[category]++
+	cc.drResetAt[category] = now + diminishingReturnsResetTime
+	return duration
+}
+
+// Whether a stun, fear or incapacitate keeps the unit from acting.
+func (cc *crowdControl) isControlled() bool {
+	return cc.auras[CrowdControlStun].IsActive() ||
+		cc.auras[CrowdControlFear].IsActive() ||
+		cc.auras[CrowdControlIncapacitate].IsActive()
+}
+
+// Silences only prevent spells, physical abilities like Heroic Strike stay usable.
+func isSilenceable(spell *Spell) bool {
+	return spell.SpellSchool != SpellSchoolNone && spell.SpellSchool != SpellSchoolPhysical
+}
+
+// Applies a crowd control effect to the unit, shortened by diminishing returns. Returns the
+// duration applied, which is 0 if the unit is immune.
+func (unit *Unit) ApplyCrowdControl(sim *Simulation, category CrowdControlCategory, duration time.Duration) time.Duration {
+	cc := unit.crowdControl
+	if cc == nil || duration <= 0 {
+		return 0
+	}
+
+	duration = cc.diminishDuration(sim.CurrentTime, category, duration)
+	if duration == 0 {
+		if sim.Log != nil {
+			unit.Log(sim, "Immune to %s due to diminishing returns", crowdControlLabels[category])
+		}
+		return 0
+	}
+
+	aura := cc.auras[category]
+	aura.Duration = duration
+	aura.Activate(sim)
+	return duration
+}
+
+// Whether the unit is stunned, feared or incapacitated.
+func (unit *Unit) IsCrowdControlled() bool {
+	return unit.crowdControl != nil && unit.crowdControl.isControlled()
+}
+
+// Whether the unit can be interrupted and crowd controlled. Only duelists can, so class
+// interrupts leave the casts of encounter targets alone.
+func (unit *Unit) CanBeCrowdControlled() bool {
+	return unit.crowdControl != nil
+}
+
+// Interrupts the unit's cast or channel, locking it out of the spell's school for the given
+// duration. Returns whether anything was interrupted.
+func (unit *Unit) InterruptCast(sim *Simulation, lockout time.Duration) bool {
+	var spell *Spell
+	if unit.IsChanneling(sim) {
+		spell = unit.ChanneledDot.Spell
+		if sim.Log != nil {
+			unit.Log(sim, "Channel of %s interrupted", spell.ActionID)
+		}
+		unit.ChanneledDot.Cancel(sim)
+	} else if unit.IsCasting(sim) {
+		spell = unit.GetSpell(unit.Hardcast.ActionID)
+		if sim.Log != nil {
+			unit.Log(sim, "Cast of %s interrupted", unit.Hardcast.ActionID)
+		}
+		unit.cancelHardcast(sim)
+	} else {
+		return false
+	}
+
+	if cc := unit.crowdControl; cc != nil && spell != nil && lockout > 0 {
+		for _, schoolIndex := range spell.SchoolBaseIndices {
+			cc.schoolLockouts[schoolIndex] = max(cc.schoolLockouts[schoolIndex], sim.CurrentTime+lockout)
+		}
+	}
+	return true
+}
+
+// Whether crowd control, a silence or a school lockout keeps the unit from using the spell.
+// Procs and other effects the unit doesn't actively use keep working.
+func (unit *Unit) isPreventedFromCasting(sim *Simulation, spell *Spell) bool {
+	cc := unit.crowdControl
+	if cc == nil || !spell.Flags.Matches(SpellFlagAPL) {
+		return false
+	}
+	if cc.isControlled() {
+		return true
+	}
+	if !isSilenceable(spell) {
+		return false
+	}
+	if cc.auras[CrowdControlSilence].IsActive() {
+		return true
+	}
+	for _, schoolIndex := range spell.SchoolBaseIndices {
+		if cc.schoolLockouts[schoolIndex] > sim.CurrentTime {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,44 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDiminishingReturns(t *testing.T) {
+	cc := &crowdControl{}
+	stun := time.Second * 4
+
+	expected := []time.Duration{stun, stun / 2, stun / 4, 0, 0}
+	for i, want := range expected {
+		if got := cc.diminishDuration(time.Second*time.Duration(i), CrowdControlStun, stun); got != want {
+			t.Fatalf("Stun %d: expected %s, got %s", i+1, want, got)
+		}
+	}
+
+	// Other categories have their own diminishing returns.
+	if got := cc.diminishDuration(time.Second*5, CrowdControlFear, stun); got != stun {
+		t.Fatalf("Expected a full fear, got %s", got)
+	}
+
+	// Immune applications don't extend the reset window, which started with the 3rd stun.
+	if got := cc.diminishDuration(time.Second*17, CrowdControlStun, stun); got != stun {
+		t.Fatalf("Expected a full stun after the reset, got %s", got)
+	}
+}
+
+func TestPvPAttackTable(t *testing.T) {
+	attacker := &Unit{Type: PlayerUnit, Level: 60}
+	defender := &Unit{Type: PlayerUnit, Level: 60}
+
+	table := NewPvPAttackTable(attacker, defender, nil)
+	if !table.IsPvP || table.BaseMissChance != 0.05 || table.BaseSpellMissChance != 0.04 || table.BaseGlanceChance != 0 || table.BaseCrushChance != 0 {
+		t.Fatalf("Unexpected same level table: %+v", table)
+	}
+
+	defender.Level = 63
+	table = NewPvPAttackTable(attacker, defender, nil)
+	if !WithinToleranceFloat64(0.056, table.BaseMissChance, 1e-9) || table.BaseSpellMissChance != 0.13 {
+		t.Fatalf("Unexpected +3 level table: miss %f, spell miss %f", table.BaseMissChance, table.BaseSpellMissChance)
+	}
+}
@@ -72,6 +72,9 @@ type Item struct {
 	Timeworn   bool
 	Sanctified bool
 
+	// Whether the item has non-stat Equip/Use/Chance on hit effects.
+	HasEffect bool
+
 	// Modified for each instance of the item.
 	RandomSuffix RandomSuffix
 	Enchant      Enchant
@@ -104,6 +107,7 @@ func ItemFromProto(pData *proto.SimItem) Item {
 		WeaponSkills:        stats.WeaponSkillsFloatArray(pData.WeaponSkills),
 		Timeworn:            pData.Timeworn,
 		Sanctified:          pData.Sanctified,
+		HasEffect:           pData.HasEffect,
 	}
 }
 
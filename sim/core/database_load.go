@@ -39,6 +39,7 @@ func init() {
 			SetId:               item.SetId,
 			WeaponSkills:        item.WeaponSkills,
 			Timeworn:            item.Timeworn,
+			HasEffect:           item.HasEffect,
 		}
 	}
 
@@ -0,0 +1,170 @@
+package core
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+// Returns all items, enchants and runes from db matching the request filters.
+// If none of the include_* flags are set, only items are searched.
+func SearchDatabase(db *proto.UIDatabase, request *proto.DatabaseSearchRequest) *proto.DatabaseSearchResult {
+	result := &proto.DatabaseSearchResult{}
+	if db == nil {
+		result.ErrorResult = "no database loaded"
+		return result
+	}
+
+	includeItems := request.IncludeItems || (!request.IncludeEnchants && !request.IncludeRunes)
+	name := strings.ToLower(request.Name)
+
+	if includeItems {
+		var npcsInZone map[int32]bool
+		if request.ZoneId != 0 {
+			npcsInZone = make(map[int32]bool)
+			for _, npc := range db.Npcs {
+				if npc.ZoneId == request.ZoneId {
+					npcsInZone[npc.Id] = true
+				}
+			}
+		}
+
+		for _, item := range db.Items {
+			if request.Limit > 0 && len(result.Items) >= int(request.Limit) {
+				break
+			}
+			if itemMatchesSearch(item, request, name, npcsInZone) {
+				result.Items = append(result.Items, item)
+			}
+		}
+	}
+
+	if request.IncludeEnchants {
+		for _, enchant := range db.Enchants {
+			if request.Limit > 0 && len(result.Enchants) >= int(request.Limit) {
+				break
+			}
+			if enchantMatchesSearch(enchant, request, name) {
+				result.Enchants = append(result.Enchants, enchant)
+			}
+		}
+	}
+
+	if request.IncludeRunes {
+		for _, uiRune := range db.Runes {
+			if request.Limit > 0 && len(result.Runes) >= int(request.Limit) {
+				break
+			}
+			if name != "" && !strings.Contains(strings.ToLower(uiRune.Name), name) {
+				continue
+			}
+			if request.Type != proto.ItemType_ItemTypeUnknown && uiRune.Type != request.Type {
+				continue
+			}
+			if !classAllowed(uiRune.ClassAllowlist, request.Class) {
+				continue
+			}
+			result.Runes = append(result.Runes, uiRune)
+		}
+	}
+
+	return result
+}
+
+func itemMatchesSearch(item *proto.UIItem, request *proto.DatabaseSearchRequest, name string, npcsInZone map[int32]bool) bool {
+	if name != "" && !strings.Contains(strings.ToLower(item.Name), name) {
+		return false
+	}
+	if request.Type != proto.ItemType_ItemTypeUnknown && item.Type != request.Type {
+		return false
+	}
+	if len(request.ArmorTypes) > 0 && !slices.Contains(request.ArmorTypes, item.ArmorType) {
+		return false
+	}
+	if len(request.WeaponTypes) > 0 && !slices.Contains(request.WeaponTypes, item.WeaponType) {
+		return false
+	}
+	if len(request.HandTypes) > 0 && !slices.Contains(request.HandTypes, item.HandType) {
+		return false
+	}
+	if len(request.RangedWeaponTypes) > 0 && !slices.Contains(request.RangedWeaponTypes, item.RangedWeaponType) {
+		return false
+	}
+	if len(request.Phases) > 0 && !slices.Contains(request.Phases, item.Phase) {
+		return false
+	}
+	if item.Quality < request.MinQuality {
+		return false
+	}
+	if !classAllowed(item.ClassAllowlist, request.Class) {
+		return false
+	}
+	if !statsMeetMinimum(item.Stats, request.MinStats) {
+		return false
+	}
+	if request.ZoneId != 0 || request.NpcId != 0 {
+		if !slices.ContainsFunc(item.Sources, func(source *proto.UIItemSource) bool {
+			return sourceMatches(source, request, npcsInZone)
+		}) {
+			return false
+		}
+	}
+	return true
+}
+
+func enchantMatchesSearch(enchant *proto.UIEnchant, request *proto.DatabaseSearchRequest, name string) bool {
+	if name != "" && !strings.Contains(strings.ToLower(enchant.Name), name) {
+		return false
+	}
+	if request.Type != proto.ItemType_ItemTypeUnknown && enchant.Type != request.Type && !slices.Contains(enchant.ExtraTypes, request.Type) {
+		return false
+	}
+	if len(request.Phases) > 0 && !slices.Contains(request.Phases, enchant.Phase) {
+		return false
+	}
+	if enchant.Quality < request.MinQuality {
+		return false
+	}
+	if !classAllowed(enchant.ClassAllowlist, request.Class) {
+		return false
+	}
+	return statsMeetMinimum(enchant.Stats, request.MinStats)
+}
+
+func sourceMatches(source *proto.UIItemSource, request *proto.DatabaseSearchRequest, npcsInZone map[int32]bool) bool {
+	var zoneId, npcId int32
+	switch src := source.Source.(type) {
+	case *proto.UIItemSource_Drop:
+		zoneId, npcId = src.Drop.ZoneId, src.Drop.NpcId
+	case *proto.UIItemSource_SoldBy:
+		zoneId, npcId = src.SoldBy.ZoneId, src.SoldBy.NpcId
+	default:
+		return false
+	}
+
+	if request.NpcId != 0 && npcId != request.NpcId {
+		return false
+	}
+	if request.ZoneId != 0 && zoneId != request.ZoneId && !npcsInZone[npcId] {
+		return false
+	}
+	return true
+}
+
+// An empty allowlist means the entry has no class restrictions.
+func classAllowed(allowlist []proto.Class, class proto.Class) bool {
+	return class == proto.Class_ClassUnknown || len(allowlist) == 0 || slices.Contains(allowlist, class)
+}
+
+func statsMeetMinimum(values []float64, minimums []float64) bool {
+	for i, minimum := range minimums {
+		if minimum == 0 {
+			continue
+		}
+		if i >= len(values) || values[i] < minimum {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,56 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/wowsims/sod/sim/core/proto"
+	"github.com/wowsims/sod/sim/core/stats"
+)
+
+func searchTestDatabase() *proto.UIDatabase {
+	hitStats := make([]float64, stats.Len)
+	hitStats[stats.MeleeHit] = 1
+
+	return &proto.UIDatabase{
+		Items: []*proto.UIItem{
+			{Id: 1, Name: "Hit Mace", Type: proto.ItemType_ItemTypeWeapon, WeaponType: proto.WeaponType_WeaponTypeMace, HandType: proto.HandType_HandTypeTwoHand, Phase: 5, Stats: hitStats,
+				Sources: []*proto.UIItemSource{{Source: &proto.UIItemSource_Drop{Drop: &proto.DropSource{NpcId: 10}}}}},
+			{Id: 2, Name: "Plain Mace", Type: proto.ItemType_ItemTypeWeapon, WeaponType: proto.WeaponType_WeaponTypeMace, HandType: proto.HandType_HandTypeTwoHand, Phase: 5},
+			{Id: 3, Name: "Old Hit Mace", Type: proto.ItemType_ItemTypeWeapon, WeaponType: proto.WeaponType_WeaponTypeMace, HandType: proto.HandType_HandTypeTwoHand, Phase: 4, Stats: hitStats},
+			{Id: 4, Name: "Priest Robe", Type: proto.ItemType_ItemTypeChest, ArmorType: proto.ArmorType_ArmorTypeCloth, Phase: 5, ClassAllowlist: []proto.Class{proto.Class_ClassPriest}},
+		},
+		Npcs: []*proto.UINPC{{Id: 10, ZoneId: 100}},
+	}
+}
+
+func TestSearchDatabaseFilters(t *testing.T) {
+	db := searchTestDatabase()
+	minStats := make([]float64, stats.Len)
+	minStats[stats.MeleeHit] = 1
+
+	result := SearchDatabase(db, &proto.DatabaseSearchRequest{
+		Type:        proto.ItemType_ItemTypeWeapon,
+		WeaponTypes: []proto.WeaponType{proto.WeaponType_WeaponTypeMace},
+		HandTypes:   []proto.HandType{proto.HandType_HandTypeTwoHand},
+		Phases:      []int32{5},
+		MinStats:    minStats,
+	})
+	if len(result.Items) != 1 || result.Items[0].Id != 1 {
+		t.Fatalf("Expected only item 1, got %v", result.Items)
+	}
+
+	result = SearchDatabase(db, &proto.DatabaseSearchRequest{ZoneId: 100})
+	if len(result.Items) != 1 || result.Items[0].Id != 1 {
+		t.Fatalf("Expected zone filter to resolve drop NPC zone, got %v", result.Items)
+	}
+
+	result = SearchDatabase(db, &proto.DatabaseSearchRequest{Class: proto.Class_ClassWarrior})
+	if len(result.Items) != 3 {
+		t.Fatalf("Expected class-restricted item to be excluded, got %d items", len(result.Items))
+	}
+
+	result = SearchDatabase(db, &proto.DatabaseSearchRequest{Name: "hit", Limit: 1})
+	if len(result.Items) != 1 {
+		t.Fatalf("Expected limit to cap results, got %d items", len(result.Items))
+	}
+}
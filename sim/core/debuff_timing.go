@@ -0,0 +1,158 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wowsims/sod/sim/core/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	defaultDebuffStackInterval   = time.Millisecond * 1500
+	defaultDebuffRefreshInterval = time.Second * 30
+)
+
+// Indexes the debuff timings by Debuffs field name. Invalid timings panic, so typos are
+// reported instead of silently falling back to permanent debuffs.
+func debuffTimings(debuffs *proto.Debuffs) map[string]*proto.DebuffTiming {
+	fields := debuffs.ProtoReflect().Descriptor().Fields()
+	timings := make(map[string]*proto.DebuffTiming, len(debuffs.Timings))
+	for _, timing := range debuffs.Timings {
+		field := fields.ByName(protoreflect.Name(timing.Debuff))
+		if field == nil || field.IsList() {
+			panic(fmt.Sprintf("Debuff timing for unknown debuff %q", timing.Debuff))
+		}
+		if timing.Uptime < 0 || timing.Uptime > 1 {
+			panic(fmt.Sprintf("Debuff timing for %s: uptime must be between 0 and 1, got %f", timing.Debuff, timing.Uptime))
+		}
+		if timing.RefreshGapSeconds < 0 || timing.RefreshIntervalSeconds < 0 {
+			panic(fmt.Sprintf("Debuff timing for %s: refresh gap and interval can't be negative", timing.Debuff))
+		}
+		if timing.RefreshIntervalSeconds > 0 && timing.RefreshGapSeconds >= timing.RefreshIntervalSeconds {
+			panic(fmt.Sprintf("Debuff timing for %s: refresh gap must be shorter than the refresh interval", timing.Debuff))
+		}
+		timings[timing.Debuff] = timing
+	}
+	return timings
+}
+
+// Applies an external debuff from pull, or on the timeline given by timing.
+func applyExternalDebuff(aura *Aura, timing *proto.DebuffTiming) {
+	if aura == nil {
+		return
+	}
+	if timing == nil {
+		MakePermanent(aura)
+		return
+	}
+	scheduleExternalDebuff(aura, timing, 0)
+}
+
+// Like applyExternalDebuff, for debuffs which ramp up to 5 stacks after pull.
+func applyStackingExternalDebuff(aura *Aura, timing *proto.DebuffTiming) {
+	if timing != nil {
+		scheduleExternalDebuff(aura, timing, 0)
+		return
+	}
+
+	SchedulePeriodicDebuffApplication(aura, PeriodicActionOptions{
+		Period:          defaultDebuffStackInterval,
+		NumTicks:        5,
+		TickImmediately: true,
+		Priority:        ActionPriorityDOT, // High prio so it comes before actual player applications.
+		OnAction: func(sim *Simulation) {
+			aura.Activate(sim)
+			if aura.IsActive() {
+				aura.AddStack(sim)
+			}
+		},
+	})
+}
+
+// Applies the debuff after the configured delay, ramping stacking debuffs up one stack at a time.
+// With a refresh gap or uptime, the debuff drops once per refresh interval and is reapplied
+// after the gap, starting over from a single stack.
+func scheduleExternalDebuff(aura *Aura, timing *proto.DebuffTiming, defaultDelay time.Duration) {
+	delay := defaultDelay
+	if timing.DelaySeconds > 0 {
+		delay = DurationFromSeconds(timing.DelaySeconds)
+	}
+
+	stackInterval := defaultDebuffStackInterval
+	if timing.StackIntervalSeconds > 0 {
+		stackInterval = DurationFromSeconds(timing.StackIntervalSeconds)
+	} else if timing.StackIntervalSeconds < 0 {
+		stackInterval = 0
+	}
+
+	refreshInterval := defaultDebuffRefreshInterval
+	if timing.RefreshIntervalSeconds > 0 {
+		refreshInterval = DurationFromSeconds(timing.RefreshIntervalSeconds)
+	}
+	refreshGap := DurationFromSeconds(timing.RefreshGapSeconds)
+	if timing.Uptime > 0 {
+		refreshGap = time.Duration(float64(refreshInterval) * (1 - timing.Uptime))
+	}
+
+	var ramp *PendingAction
+	var apply func(sim *Simulation)
+	apply = func(sim *Simulation) {
+		aura.Activate(sim)
+
+		if aura.MaxStacks > 0 {
+			if stackInterval == 0 {
+				aura.SetStacks(sim, aura.MaxStacks)
+			} else {
+				aura.SetStacks(sim, 1)
+				ramp = StartPeriodicAction(sim, PeriodicActionOptions{
+					Period:   stackInterval,
+					NumTicks: int(aura.MaxStacks - 1),
+					Priority: ActionPriorityDOT,
+					OnAction: func(sim *Simulation) {
+						if aura.IsActive() {
+							aura.AddStack(sim)
+						}
+					},
+				})
+			}
+		}
+
+		if refreshGap > 0 {
+			StartDelayedAction(sim, DelayedActionOptions{
+				DoAt:     sim.CurrentTime + refreshInterval - refreshGap,
+				Priority: ActionPriorityDOT,
+				OnAction: func(sim *Simulation) {
+					if ramp != nil {
+						ramp.Cancel(sim)
+						ramp = nil
+					}
+					aura.Deactivate(sim)
+					StartDelayedAction(sim, DelayedActionOptions{
+						DoAt:     sim.CurrentTime + refreshGap,
+						Priority: ActionPriorityDOT,
+						OnAction: apply,
+					})
+				},
+			})
+		}
+	}
+
+	aura.Duration = NeverExpires
+	oldOnReset := aura.OnReset
+	aura.OnReset = func(aura *Aura, sim *Simulation) {
+		if oldOnReset != nil {
+			oldOnReset(aura, sim)
+		}
+		ramp = nil
+		if delay == 0 {
+			apply(sim)
+		} else {
+			StartDelayedAction(sim, DelayedActionOptions{
+				DoAt:     delay,
+				Priority: ActionPriorityDOT,
+				OnAction: apply,
+			})
+		}
+	}
+}
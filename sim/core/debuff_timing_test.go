@@ -0,0 +1,32 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+func TestDebuffTimings(t *testing.T) {
+	timings := debuffTimings(&proto.Debuffs{
+		Timings: []*proto.DebuffTiming{{Debuff: "sunder_armor", DelaySeconds: 2}},
+	})
+	if timings["sunder_armor"].DelaySeconds != 2 {
+		t.Fatalf("Expected sunder_armor timing, got %v", timings)
+	}
+
+	for _, timing := range []*proto.DebuffTiming{
+		{Debuff: "sunder_armour"},
+		{Debuff: "timings"},
+		{Debuff: "faerie_fire", Uptime: 1.5},
+		{Debuff: "faerie_fire", RefreshIntervalSeconds: 10, RefreshGapSeconds: 10},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("Expected invalid timing %v to panic", timing)
+				}
+			}()
+			debuffTimings(&proto.Debuffs{Timings: []*proto.DebuffTiming{timing}})
+		}()
+	}
+}
@@ -31,25 +31,21 @@ var LevelToDebuffRank = map[DebuffName]map[int32]int32{
 }
 
 func applyDebuffEffects(target *Unit, targetIdx int, debuffs *proto.Debuffs, level int32, units []*Unit) {
+	timings := debuffTimings(debuffs)
+
 	if debuffs.JudgementOfWisdom && targetIdx == 0 {
-		jowAura := JudgementOfWisdomAura(target, level)
-		if jowAura != nil {
-			MakePermanent(jowAura)
-		}
+		applyExternalDebuff(JudgementOfWisdomAura(target, level), timings["judgement_of_wisdom"])
 	}
 
 	if debuffs.JudgementOfLight && targetIdx == 0 {
-		jolAura := JudgementOfLightAura(target, level, units)
-		if jolAura != nil {
-			MakePermanent(jolAura)
-		}
+		applyExternalDebuff(JudgementOfLightAura(target, level, units), timings["judgement_of_light"])
 	}
 
 	if targetIdx == 0 {
 		if debuffs.JudgementOfTheCrusader == proto.TristateEffect_TristateEffectRegular {
-			MakePermanent(JudgementOfTheCrusaderAura(nil, target, level, 1, 0))
+			applyExternalDebuff(JudgementOfTheCrusaderAura(nil, target, level, 1, 0), timings["judgement_of_the_crusader"])
 		} else if debuffs.JudgementOfTheCrusader == proto.TristateEffect_TristateEffectImproved {
-			MakePermanent(JudgementOfTheCrusaderAura(nil, target, level, 1.15, 0))
+			applyExternalDebuff(JudgementOfTheCrusaderAura(nil, target, level, 1.15, 0), timings["judgement_of_the_crusader"])
 		}
 	}
 
@@ -58,216 +54,163 @@ func applyDebuffEffects(target *Unit, targetIdx int, debuffs *proto.Debuffs, lev
 	}
 
 	if debuffs.ShadowWeaving {
-		aura := ShadowWeavingAura(target, 5)
-		SchedulePeriodicDebuffApplication(aura, PeriodicActionOptions{
-			Period:          time.Millisecond * 1500,
-			NumTicks:        5,
-			TickImmediately: true,
-			Priority:        ActionPriorityDOT, // High prio
-			OnAction: func(sim *Simulation) {
-				aura.Activate(sim)
-				if aura.IsActive() {
-					aura.AddStack(sim)
-				}
-			},
-		})
+		applyStackingExternalDebuff(ShadowWeavingAura(target, 5), timings["shadow_weaving"])
 	}
 
 	if debuffs.OccultPoison {
-		aura := OccultPoisonDebuffAura(target, level)
-		SchedulePeriodicDebuffApplication(aura, PeriodicActionOptions{
-			Period:          time.Millisecond * 1500,
-			NumTicks:        5,
-			TickImmediately: true,
-			Priority:        ActionPriorityDOT, // High prio
-			OnAction: func(sim *Simulation) {
-				aura.Activate(sim)
-				if aura.IsActive() {
-					aura.AddStack(sim)
-				}
-			},
-		})
+		applyStackingExternalDebuff(OccultPoisonDebuffAura(target, level), timings["occult_poison"])
 	}
 
 	if debuffs.MekkatorqueFistDebuff {
-		MakePermanent(MekkatorqueFistDebuffAura(target, level))
+		applyExternalDebuff(MekkatorqueFistDebuffAura(target, level), timings["mekkatorque_fist_debuff"])
 	}
 
 	if debuffs.SerpentsStrikerFistDebuff {
-		MakePermanent(SerpentsStrikerFistDebuffAura(target, level))
+		applyExternalDebuff(SerpentsStrikerFistDebuffAura(target, level), timings["serpents_striker_fist_debuff"])
 	}
 
 	if debuffs.MarkOfChaos {
-		MakePermanent(MarkOfChaosDebuffAura(target))
+		applyExternalDebuff(MarkOfChaosDebuffAura(target), timings["mark_of_chaos"])
 	} else {
 		if debuffs.CurseOfElements {
-			MakePermanent(CurseOfElementsAura(target, level))
+			applyExternalDebuff(CurseOfElementsAura(target, level), timings["curse_of_elements"])
 		}
 
 		if debuffs.CurseOfShadow {
-			MakePermanent(CurseOfShadowAura(target, level))
+			applyExternalDebuff(CurseOfShadowAura(target, level), timings["curse_of_shadow"])
 		}
 	}
 
 	if debuffs.ImprovedScorch && targetIdx == 0 {
-		aura := ImprovedScorchAura(target)
-		SchedulePeriodicDebuffApplication(aura, PeriodicActionOptions{
-			Period:          time.Millisecond * 1500,
-			NumTicks:        5,
-			TickImmediately: true,
-			Priority:        ActionPriorityDOT, // High prio
-			OnAction: func(sim *Simulation) {
-				aura.Activate(sim)
-				if aura.IsActive() {
-					aura.AddStack(sim)
-				}
-			},
-		})
+		applyStackingExternalDebuff(ImprovedScorchAura(target), timings["improved_scorch"])
 	}
 
 	if debuffs.WintersChill && targetIdx == 0 {
-		aura := WintersChillAura(target)
-		SchedulePeriodicDebuffApplication(aura, PeriodicActionOptions{
-			Period:          time.Millisecond * 1500,
-			NumTicks:        5,
-			TickImmediately: true,
-			Priority:        ActionPriorityDOT, // High prio
-			OnAction: func(sim *Simulation) {
-				aura.Activate(sim)
-				if aura.IsActive() {
-					aura.AddStack(sim)
-				}
-			},
-		})
+		applyStackingExternalDebuff(WintersChillAura(target), timings["winters_chill"])
 	}
 
 	if debuffs.Stormstrike {
-		MakePermanent(StormstrikeAura(target))
+		applyExternalDebuff(StormstrikeAura(target), timings["stormstrike"])
 	} else if debuffs.Dreamstate {
-		MakePermanent(DreamstateAura(target))
+		applyExternalDebuff(DreamstateAura(target), timings["dreamstate"])
 	}
 
 	if debuffs.GiftOfArthas {
-		MakePermanent(GiftOfArthasAura(target))
+		applyExternalDebuff(GiftOfArthasAura(target), timings["gift_of_arthas"])
 	}
 
 	if debuffs.HolySunder {
-		MakePermanent(HolySunderAura(target))
+		applyExternalDebuff(HolySunderAura(target), timings["holy_sunder"])
 	}
 
 	if debuffs.CurseOfVulnerability {
-		MakePermanent(CurseOfVulnerabilityAura(target))
+		applyExternalDebuff(CurseOfVulnerabilityAura(target), timings["curse_of_vulnerability"])
 	}
 
 	if debuffs.Mangle {
-		MakePermanent(MangleAura(target, level))
+		applyExternalDebuff(MangleAura(target, level), timings["mangle"])
 	}
 
 	// Major Armor Debuffs
 	if targetIdx == 0 {
 		if debuffs.ExposeArmor != proto.TristateEffect_TristateEffectMissing {
 			aura := ExposeArmorAura(target, TernaryInt32(debuffs.ExposeArmor == proto.TristateEffect_TristateEffectRegular, 0, 2), level)
-			SchedulePeriodicDebuffApplication(aura, PeriodicActionOptions{
-				Period:   time.Second * 3,
-				NumTicks: 1,
-				OnAction: func(sim *Simulation) {
-					aura.Activate(sim)
-				},
-			})
+			if timing := timings["expose_armor"]; timing != nil {
+				scheduleExternalDebuff(aura, timing, time.Second*3)
+			} else {
+				SchedulePeriodicDebuffApplication(aura, PeriodicActionOptions{
+					Period:   time.Second * 3,
+					NumTicks: 1,
+					OnAction: func(sim *Simulation) {
+						aura.Activate(sim)
+					},
+				})
+			}
 		}
 
 		if debuffs.SebaciousPoison != proto.TristateEffect_TristateEffectMissing {
 			aura := SebaciousPoisonAura(target, TernaryInt32(debuffs.SebaciousPoison == proto.TristateEffect_TristateEffectRegular, 0, 2), level)
-			SchedulePeriodicDebuffApplication(aura, PeriodicActionOptions{
-				Period:   time.Second * 0,
-				NumTicks: 1,
-				OnAction: func(sim *Simulation) {
-					aura.Activate(sim)
-				},
-			})
+			if timing := timings["sebacious_poison"]; timing != nil {
+				scheduleExternalDebuff(aura, timing, 0)
+			} else {
+				SchedulePeriodicDebuffApplication(aura, PeriodicActionOptions{
+					Period:   time.Second * 0,
+					NumTicks: 1,
+					OnAction: func(sim *Simulation) {
+						aura.Activate(sim)
+					},
+				})
+			}
 		}
 
 		if debuffs.SunderArmor {
-			// Sunder Armor
-			aura := SunderArmorAura(target, level)
-			SchedulePeriodicDebuffApplication(aura, PeriodicActionOptions{
-				Period:          time.Millisecond * 1500,
-				NumTicks:        5,
-				TickImmediately: true,
-				Priority:        ActionPriorityDOT, // High prio so it comes before actual warrior sunders.
-				OnAction: func(sim *Simulation) {
-					aura.Activate(sim)
-					if aura.IsActive() {
-						aura.AddStack(sim)
-					}
-				},
-			})
+			applyStackingExternalDebuff(SunderArmorAura(target, level), timings["sunder_armor"])
 		}
 	}
 
 	if debuffs.CurseOfRecklessness {
-		MakePermanent(CurseOfRecklessnessAura(target, level))
+		applyExternalDebuff(CurseOfRecklessnessAura(target, level), timings["curse_of_recklessness"])
 	}
 
 	if debuffs.FaerieFire || debuffs.ImprovedFaerieFire {
-		MakePermanent(FaerieFireAura(target, level))
+		applyExternalDebuff(FaerieFireAura(target, level), timings["faerie_fire"])
 	}
 
 	if debuffs.ImprovedFaerieFire {
-		MakePermanent(ImprovedFaerieFireAura(target))
+		applyExternalDebuff(ImprovedFaerieFireAura(target), timings["improved_faerie_fire"])
 	}
 
 	if debuffs.MeleeHunterDodgeDebuff {
-		MakePermanent(MeleeHunterDodgeReductionAura(target, level))
+		applyExternalDebuff(MeleeHunterDodgeReductionAura(target, level), timings["melee_hunter_dodge_debuff"])
 	}
 
 	if debuffs.CurseOfWeakness != proto.TristateEffect_TristateEffectMissing {
-		MakePermanent(CurseOfWeaknessAura(target, GetTristateValueInt32(debuffs.CurseOfWeakness, 0, 3), level))
+		applyExternalDebuff(CurseOfWeaknessAura(target, GetTristateValueInt32(debuffs.CurseOfWeakness, 0, 3), level), timings["curse_of_weakness"])
 	}
 
 	if debuffs.DemoralizingRoar != proto.TristateEffect_TristateEffectMissing {
-		MakePermanent(DemoralizingRoarAura(target, GetTristateValueInt32(debuffs.DemoralizingRoar, 0, 5), level))
+		applyExternalDebuff(DemoralizingRoarAura(target, GetTristateValueInt32(debuffs.DemoralizingRoar, 0, 5), level), timings["demoralizing_roar"])
 	}
 	if debuffs.DemoralizingShout != proto.TristateEffect_TristateEffectMissing {
-		MakePermanent(DemoralizingShoutAura(target, 0, GetTristateValueInt32(debuffs.DemoralizingShout, 0, 5), level))
+		applyExternalDebuff(DemoralizingShoutAura(target, 0, GetTristateValueInt32(debuffs.DemoralizingShout, 0, 5), level), timings["demoralizing_shout"])
 	}
 	if debuffs.AtrophicPoison {
-		MakePermanent(AtrophicPoisonAura(target))
+		applyExternalDebuff(AtrophicPoisonAura(target), timings["atrophic_poison"])
 	}
 
 	if debuffs.HuntersMark != proto.TristateEffect_TristateEffectMissing {
-		MakePermanent(HuntersMarkAura(target, GetTristateValueInt32(debuffs.HuntersMark, 0, 5), level))
+		applyExternalDebuff(HuntersMarkAura(target, GetTristateValueInt32(debuffs.HuntersMark, 0, 5), level), timings["hunters_mark"])
 	}
 
 	// Atk spd reduction
 	if debuffs.ThunderClap != proto.TristateEffect_TristateEffectMissing {
 		// +6% from Furious Thunder rune
-		MakePermanent(ThunderClapAura(target, 8205, time.Second*10, GetTristateValueInt32(debuffs.ThunderClap, 10, 16)))
+		applyExternalDebuff(ThunderClapAura(target, 8205, time.Second*10, GetTristateValueInt32(debuffs.ThunderClap, 10, 16)), timings["thunder_clap"])
 	}
 	if debuffs.Waylay {
-		MakePermanent(WaylayAura(target))
+		applyExternalDebuff(WaylayAura(target), timings["waylay"])
 	}
 	if debuffs.Thunderfury {
-		MakePermanent(ThunderfuryASAura(target, level))
+		applyExternalDebuff(ThunderfuryASAura(target, level), timings["thunderfury"])
 	}
 	if debuffs.NumbingPoison {
-		MakePermanent(NumbingPoisonAura(target))
+		applyExternalDebuff(NumbingPoisonAura(target), timings["numbing_poison"])
 	}
 
 	// Miss
 	if debuffs.InsectSwarm && targetIdx == 0 {
-		MakePermanent(InsectSwarmAura(target, level))
+		applyExternalDebuff(InsectSwarmAura(target, level), timings["insect_swarm"])
 	}
 	if debuffs.ScorpidSting && targetIdx == 0 {
-		MakePermanent(ScorpidStingAura(target))
+		applyExternalDebuff(ScorpidStingAura(target), timings["scorpid_sting"])
 	}
 
 	// Karazhan random suffixes
 	if debuffs.FrostFever {
-		MakePermanent(FrostFeverAura(target))
+		applyExternalDebuff(FrostFeverAura(target), timings["frost_fever"])
 	}
 	if debuffs.BloodPlague {
-		MakePermanent(BloodPlagueAura(target))
+		applyExternalDebuff(BloodPlagueAura(target), timings["blood_plague"])
 	}
 }
 
@@ -0,0 +1,235 @@
+package core
+
+import (
+	"fmt"
+	"math"
+	"runtime/debug"
+	"time"
+
+	"github.com/wowsims/sod/sim/core/proto"
+	"github.com/wowsims/sod/sim/core/simsignals"
+	googleProto "google.golang.org/protobuf/proto"
+)
+
+const (
+	defaultDuelStartingDistance = 20.0
+	defaultDuelMaxDuration      = 180.0
+
+	// The encounter still needs a target, which stands this far away from both players so
+	// AoE effects can't reach it.
+	duelTargetDistance = 1000.0
+)
+
+// Two players on their own parties, teams 1 and 2, fighting each other. Pets join the team of
+// their owner, but only the players can die.
+type duelState struct {
+	startingDistance float64
+
+	players [2]*Character
+	teams   [2][]*Unit
+
+	// Team of the player still alive once the other one died, 0 while both are alive.
+	winner   int32
+	killTime time.Duration
+}
+
+func (unit *Unit) isDuelOpponent(other *Unit) bool {
+	return unit.duelTeam != 0 && other.duelTeam != 0 && unit.duelTeam != other.duelTeam
+}
+
+func (duel *duelState) opponentUnits(team int32) []*Unit {
+	return duel.teams[2-team]
+}
+
+// Splits the raid into the two teams, makes everyone target the opposing player and places
+// the players facing each other.
+func (duel *duelState) construct(env *Environment) {
+	for i, party := range env.Raid.Parties {
+		duel.players[i] = party.Players[0].GetCharacter()
+		for _, playerOrPet := range party.PlayersAndPets {
+			unit := &playerOrPet.GetCharacter().Unit
+			unit.duelTeam = int32(i + 1)
+			duel.teams[i] = append(duel.teams[i], unit)
+		}
+	}
+
+	for i, team := range duel.teams {
+		for _, unit := range team {
+			unit.CurrentTarget = &duel.players[1-i].Unit
+		}
+	}
+
+	env.Positional = true
+	for i, player := range duel.players {
+		position := Vector2{X: (float64(i) - 0.5) * duel.startingDistance}
+		player.startPosition = &position
+	}
+	targetPosition := Vector2{Y: duelTargetDistance}
+	env.Encounter.TargetUnits[0].startPosition = &targetPosition
+}
+
+func (env *Environment) setupPvPAttackTables(attacker *Unit, defender *Unit, tables map[proto.CastType]*AttackTable) {
+	if attacker.Type != PlayerUnit {
+		tables[proto.CastType_CastTypeMainHand] = NewPvPAttackTable(attacker, defender, nil)
+		return
+	}
+
+	character := env.Raid.GetPlayerFromUnit(attacker).GetCharacter()
+	tables[proto.CastType_CastTypeMainHand] = NewPvPAttackTable(attacker, defender, character.GetMHWeapon())
+	tables[proto.CastType_CastTypeOffHand] = NewPvPAttackTable(attacker, defender, character.GetOHWeapon())
+	tables[proto.CastType_CastTypeRanged] = NewPvPAttackTable(attacker, defender, character.GetRangedWeapon())
+}
+
+// Raid buffs supplied by the party's own player, e.g. their Battle Shout.
+func (party *Party) duelRaidBuffs() *proto.RaidBuffs {
+	raidBuffs := &proto.RaidBuffs{}
+	for _, player := range party.Players {
+		player.AddRaidBuffs(raidBuffs)
+		player.GetCharacter().AddRaidBuffs(raidBuffs)
+	}
+	return raidBuffs
+}
+
+// Lets the player be crowd controlled, and takes damage from the opponent off its health
+// until it dies, which ends the iteration.
+func (duel *duelState) registerDuelist(character *Character) {
+	character.enableCrowdControl()
+
+	character.RegisterAura(Aura{
+		Label:    "Duel",
+		Duration: NeverExpires,
+		OnReset: func(aura *Aura, sim *Simulation) {
+			duel.winner = 0
+			duel.killTime = 0
+			aura.Activate(sim)
+		},
+		OnSpellHitTaken: func(aura *Aura, sim *Simulation, spell *Spell, result *SpellResult) {
+			duel.takeDamage(sim, aura.Unit, result.Damage)
+		},
+		OnPeriodicDamageTaken: func(aura *Aura, sim *Simulation, spell *Spell, result *SpellResult) {
+			duel.takeDamage(sim, aura.Unit, result.Damage)
+		},
+	})
+}
+
+func (duel *duelState) takeDamage(sim *Simulation, unit *Unit, damage float64) {
+	if damage <= 0 || duel.winner != 0 {
+		return
+	}
+
+	unit.RemoveHealth(sim, damage, unit.DamageTakenHealthMetrics)
+	if unit.CurrentHealth() > 0 {
+		return
+	}
+
+	unit.Metrics.Died = true
+	duel.winner = 3 - unit.duelTeam
+	duel.killTime = sim.CurrentTime
+	if sim.Log != nil {
+		unit.Log(sim, "Dead")
+	}
+	sim.endCombat()
+}
+
+func runDuel(request *proto.DuelRequest, signals simsignals.Signals) (result *proto.DuelResult) {
+	if request.Player == nil || request.Opponent == nil {
+		return &proto.DuelResult{Error: &proto.ErrorOutcome{Message: "A duel needs a player and an opponent"}}
+	}
+	if request.StartingDistance < 0 || request.MaxDurationSeconds < 0 {
+		return &proto.DuelResult{Error: &proto.ErrorOutcome{Message: "Starting distance and max duration can't be negative"}}
+	}
+
+	simOptions := &proto.SimOptions{}
+	if request.SimOptions != nil {
+		simOptions = googleProto.Clone(request.SimOptions).(*proto.SimOptions)
+	}
+	if simOptions.Iterations <= 0 {
+		return &proto.DuelResult{Error: &proto.ErrorOutcome{Message: "Iterations must be positive"}}
+	}
+
+	if !simOptions.IsTest {
+		defer func() {
+			if err := recover(); err != nil {
+				result = &proto.DuelResult{
+					Error: &proto.ErrorOutcome{Message: fmt.Sprintf("%v\nStack Trace:\n%s", err, string(debug.Stack()))},
+				}
+			}
+		}()
+	}
+
+	duel := &duelState{
+		startingDistance: request.StartingDistance,
+	}
+	if duel.startingDistance == 0 {
+		duel.startingDistance = defaultDuelStartingDistance
+	}
+	maxDuration := request.MaxDurationSeconds
+	if maxDuration == 0 {
+		maxDuration = defaultDuelMaxDuration
+	}
+
+	raid := &proto.Raid{
+		Parties: []*proto.Party{
+			{Players: []*proto.Player{request.Player}},
+			{Players: []*proto.Player{request.Opponent}},
+		},
+	}
+	encounter := &proto.Encounter{
+		Duration: maxDuration,
+		Targets:  []*proto.Target{{}},
+	}
+
+	env, _, _ := setUpEnvironment(&Environment{State: Created, duel: duel}, raid, encounter, false)
+	sim := newSimWithEnv(env, simOptions, signals)
+
+	var wins [3]int32 // draws, player wins, opponent wins
+	ttk := aggregator{}
+	ttkMetrics := &proto.DistributionMetrics{
+		Hist: make(map[int32]int32),
+		Min:  math.MaxFloat64,
+	}
+
+	for i := int32(0); i < simOptions.Iterations; i++ {
+		if signals.Abort.IsTriggered() {
+			return &proto.DuelResult{Error: &proto.ErrorOutcome{Type: proto.ErrorOutcomeType_ErrorOutcomeAborted}}
+		}
+
+		sim.reseedRands(int64(i))
+		sim.runOnce()
+
+		wins[duel.winner]++
+		if duel.winner == 0 {
+			continue
+		}
+
+		seconds := duel.killTime.Seconds()
+		ttk.add(seconds)
+		ttkMetrics.Hist[int32(math.Round(seconds))]++
+		if seconds > ttkMetrics.Max {
+			ttkMetrics.Max = seconds
+			ttkMetrics.MaxSeed = sim.rand.GetSeed()
+		}
+		if seconds < ttkMetrics.Min {
+			ttkMetrics.Min = seconds
+			ttkMetrics.MinSeed = sim.rand.GetSeed()
+		}
+	}
+
+	if ttk.n > 0 {
+		ttkMetrics.Avg, ttkMetrics.Stdev = ttk.meanAndStdDev()
+	} else {
+		ttkMetrics.Min = 0
+	}
+
+	raidMetrics := sim.Raid.GetMetrics()
+	iterations := float64(simOptions.Iterations)
+	return &proto.DuelResult{
+		PlayerWinRate:   float64(wins[1]) / iterations,
+		OpponentWinRate: float64(wins[2]) / iterations,
+		DrawRate:        float64(wins[0]) / iterations,
+		TimeToKill:      ttkMetrics,
+		PlayerMetrics:   raidMetrics.Parties[0].Players[0],
+		OpponentMetrics: raidMetrics.Parties[1].Players[0],
+		IterationsDone:  simOptions.Iterations,
+	}
+}
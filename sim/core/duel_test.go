@@ -0,0 +1,173 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wowsims/sod/sim/core/proto"
+	"github.com/wowsims/sod/sim/core/simsignals"
+)
+
+func init() {
+	RegisterAgentFactory(
+		proto.Player_Warrior{},
+		proto.Spec_SpecWarrior,
+		NewFakeDuelist,
+		func(player *proto.Player, spec interface{}) {
+			playerSpec, ok := spec.(*proto.Player_Warrior)
+			if !ok {
+				panic("Invalid spec value for Warrior!")
+			}
+			player.Spec = playerSpec
+		},
+	)
+}
+
+// A duelist with a 2s cast time bolt, and a kick and a stun for its rotation to use.
+func NewFakeDuelist(char *Character, _ *proto.Player) Agent {
+	fa := &FakeAgent{
+		Character: *char,
+	}
+
+	fa.Init = func() {
+		fa.RegisterSpell(SpellConfig{
+			ActionID:    ActionID{SpellID: 1},
+			SpellSchool: SpellSchoolFire,
+			DefenseType: DefenseTypeMagic,
+			ProcMask:    ProcMaskSpellDamage,
+			Flags:       SpellFlagAPL,
+
+			Cast: CastConfig{
+				DefaultCast: Cast{
+					GCD:      GCDDefault,
+					CastTime: time.Second * 2,
+				},
+			},
+
+			DamageMultiplier: 1,
+			ThreatMultiplier: 1,
+
+			ApplyEffects: func(sim *Simulation, target *Unit, spell *Spell) {
+				spell.CalcAndDealDamage(sim, target, 300, spell.OutcomeMagicHit)
+			},
+		})
+
+		fa.RegisterSpell(SpellConfig{
+			ActionID:    ActionID{SpellID: 2},
+			SpellSchool: SpellSchoolPhysical,
+			ProcMask:    ProcMaskEmpty,
+			Flags:       SpellFlagAPL,
+
+			Cast: CastConfig{
+				CD: Cooldown{
+					Timer:    fa.NewTimer(),
+					Duration: time.Second * 10,
+				},
+			},
+			ExtraCastCondition: func(sim *Simulation, target *Unit) bool {
+				return target.IsCasting(sim)
+			},
+
+			ApplyEffects: func(sim *Simulation, target *Unit, spell *Spell) {
+				target.InterruptCast(sim, time.Second*4)
+			},
+		})
+
+		fa.RegisterSpell(SpellConfig{
+			ActionID:    ActionID{SpellID: 3},
+			SpellSchool: SpellSchoolPhysical,
+			ProcMask:    ProcMaskEmpty,
+			Flags:       SpellFlagAPL,
+
+			Cast: CastConfig{
+				DefaultCast: Cast{
+					GCD: GCDDefault,
+				},
+				CD: Cooldown{
+					Timer:    fa.NewTimer(),
+					Duration: time.Second * 30,
+				},
+			},
+
+			ApplyEffects: func(sim *Simulation, target *Unit, spell *Spell) {
+				target.ApplyCrowdControl(sim, CrowdControlStun, time.Second*4)
+			},
+		})
+	}
+
+	return fa
+}
+
+func fakeDuelist(rotation string) *proto.Player {
+	return &proto.Player{
+		Name:      "duelist",
+		Race:      proto.Race_RaceHuman,
+		Class:     proto.Class_ClassWarrior,
+		Level:     60,
+		Equipment: &proto.EquipmentSpec{},
+		Consumes:  &proto.Consumes{},
+		Buffs:     &proto.IndividualBuffs{},
+		Spec:      &proto.Player_Warrior{},
+		Rotation:  APLRotationFromJsonString(rotation),
+	}
+}
+
+func TestDuel(t *testing.T) {
+	boltOnly := `{"type":"TypeAPL","priorityList":[{"action":{"castSpell":{"spellId":{"spellId":1}}}}]}`
+	withControl := `{"type":"TypeAPL","priorityList":[
+		{"action":{"castSpell":{"spellId":{"spellId":3}}}},
+		{"action":{"castSpell":{"spellId":{"spellId":2}}}},
+		{"action":{"castSpell":{"spellId":{"spellId":1}}}}
+	]}`
+
+	result := runDuel(&proto.DuelRequest{
+		Player:     fakeDuelist(withControl),
+		Opponent:   fakeDuelist(boltOnly),
+		SimOptions: &proto.SimOptions{Iterations: 50, IsTest: true},
+	}, simsignals.CreateSignals())
+	if result.Error != nil {
+		t.Fatalf("Duel failed: %s", result.Error.Message)
+	}
+
+	if !WithinToleranceFloat64(result.PlayerWinRate+result.OpponentWinRate+result.DrawRate, 1, 1e-9) || result.DrawRate == 1 {
+		t.Fatalf("Expected duels to end with a winner, got win rates %f and %f with %f draws", result.PlayerWinRate, result.OpponentWinRate, result.DrawRate)
+	}
+	if result.TimeToKill.Avg <= 0 || result.TimeToKill.Avg > defaultDuelMaxDuration {
+		t.Fatalf("Unexpected time to kill: %f", result.TimeToKill.Avg)
+	}
+
+	// Stuns and interrupts cost the opponent casts, so the same bolts win the duel.
+	if result.PlayerWinRate <= result.OpponentWinRate {
+		t.Fatalf("Expected crowd control to win duels, got win rates %f and %f", result.PlayerWinRate, result.OpponentWinRate)
+	}
+	playerBolt := findDuelAction(result.PlayerMetrics, 1)
+	opponentBolt := findDuelAction(result.OpponentMetrics, 1)
+	if findDuelAction(result.PlayerMetrics, 3).Casts == 0 || findDuelAction(result.PlayerMetrics, 2).Casts == 0 {
+		t.Fatalf("Expected the player to stun and interrupt")
+	}
+	if opponentBolt.Casts >= playerBolt.Casts {
+		t.Fatalf("Expected the controlled opponent to complete fewer bolts, got %d vs %d", opponentBolt.Casts, playerBolt.Casts)
+	}
+
+	// Same level players miss 4% of spells, where a level 63 encounter target resists 17%.
+	missRate := float64(playerBolt.Misses) / float64(playerBolt.Casts)
+	if missRate <= 0 || missRate > 0.1 {
+		t.Fatalf("Expected a PvP spell miss rate around 4%%, got %f", missRate)
+	}
+}
+
+// Sums the metrics of the action against all targets.
+func findDuelAction(unit *proto.UnitMetrics, spellID int32) *proto.TargetedActionMetrics {
+	total := &proto.TargetedActionMetrics{}
+	for _, action := range unit.Actions {
+		if action.Id.GetSpellId() != spellID {
+			continue
+		}
+		for _, target := range action.Targets {
+			total.Casts += target.Casts
+			total.Misses += target.Misses
+			total.Damage += target.Damage
+		}
+	}
+	return total
+}
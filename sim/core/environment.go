@@ -37,6 +37,16 @@ type Environment struct {
 	Encounter Encounter
 	AllUnits  []*Unit
 
+	// Whether any unit was placed explicitly. Otherwise range, radius and facing checks fall back
+	// to DistanceFromTarget and InFrontOfTarget, so AoE spells hit every target.
+	Positional bool
+
+	// Level cap shared by all players, which decides target defaults and debuff values.
+	LevelBracket LevelBracket
+
+	// Set when two players fight each other instead of the encounter targets, see duel.go.
+	duel *duelState
+
 	BaseDuration      time.Duration // base duration
 	DurationVariation time.Duration // variation per duration
 
@@ -48,10 +58,11 @@ type Environment struct {
 }
 
 func NewEnvironment(raidProto *proto.Raid, encounterProto *proto.Encounter, runFakePrepull bool) (*Environment, *proto.RaidStats, *proto.EncounterStats) {
-	env := &Environment{
-		State: Created,
-	}
+	return setUpEnvironment(&Environment{State: Created}, raidProto, encounterProto, runFakePrepull)
+}
 
+// Runs all phases for an environment which was created with extra settings, e.g. a duel.
+func setUpEnvironment(env *Environment, raidProto *proto.Raid, encounterProto *proto.Encounter, runFakePrepull bool) (*Environment, *proto.RaidStats, *proto.EncounterStats) {
 	env.construct(raidProto, encounterProto)
 	raidStats := env.initialize(raidProto, encounterProto)
 	env.finalize(raidProto, encounterProto, raidStats, runFakePrepull)
@@ -68,10 +79,16 @@ func NewEnvironment(raidProto *proto.Raid, encounterProto *proto.Encounter, runF
 
 // The construction phase.
 func (env *Environment) construct(raidProto *proto.Raid, encounterProto *proto.Encounter) {
-	env.Encounter = NewEncounter(encounterProto)
+	levelBracket, err := RaidLevelBracket(raidProto)
+	if err != nil {
+		panic(err)
+	}
+	env.LevelBracket = levelBracket
+
+	env.Encounter = NewEncounter(encounterProto, levelBracket)
 	env.BaseDuration = env.Encounter.Duration
 	env.DurationVariation = env.Encounter.DurationVariation
-	env.Raid = NewRaid(raidProto)
+	env.Raid = NewRaid(raidProto, levelBracket)
 
 	env.Raid.updatePlayersAndPets()
 
@@ -80,17 +97,24 @@ func (env *Environment) construct(raidProto *proto.Raid, encounterProto *proto.E
 	for unitIndex, unit := range env.AllUnits {
 		unit.Env = env
 		unit.UnitIndex = int32(unitIndex)
+		env.Positional = env.Positional || unit.startPosition != nil
 	}
 
 	for _, unit := range env.Raid.AllUnits {
 		unit.CurrentTarget = env.Encounter.TargetUnits[0]
 	}
+	if env.duel != nil {
+		env.duel.construct(env)
+	}
 
 	// Apply extra debuffs from raid.
-	if raidProto.Debuffs != nil && len(env.Encounter.TargetUnits) > 0 {
+	debuffs := raidProto.Debuffs
+	if raidProto.DeriveBuffsFromRoster {
+		debuffs = rosterDebuffs(raidProto.Debuffs, env.Raid.rosterBuffSources())
+	}
+	if debuffs != nil && len(env.Encounter.TargetUnits) > 0 {
 		for targetIdx, targetUnit := range env.Encounter.TargetUnits {
-			level := raidProto.Parties[0].Players[0].Level
-			applyDebuffEffects(targetUnit, targetIdx, raidProto.Debuffs, level, env.Raid.AllUnits)
+			applyDebuffEffects(targetUnit, targetIdx, debuffs, levelBracket.Level, env.Raid.AllUnits)
 		}
 	}
 
@@ -137,12 +161,14 @@ func (env *Environment) initialize(raidProto *proto.Raid, encounterProto *proto.
 		}
 	}
 
+	env.registerExternalBuffCasts(raidProto.ExternalBuffCasts)
+
 	env.State = Initialized
 	return raidStats
 }
 
 // The finalization phase.
-func (env *Environment) finalize(raidProto *proto.Raid, _ *proto.Encounter, raidStats *proto.RaidStats, runFakePrepull bool) {
+func (env *Environment) finalize(raidProto *proto.Raid, encounterProto *proto.Encounter, raidStats *proto.RaidStats, runFakePrepull bool) {
 	for _, finalizeEffect := range env.preFinalizeEffects {
 		finalizeEffect()
 	}
@@ -179,6 +205,8 @@ func (env *Environment) finalize(raidProto *proto.Raid, _ *proto.Encounter, raid
 		}
 	}
 
+	env.registerMovementEvents(encounterProto.GetMovementEvents())
+
 	env.setupAttackTables()
 
 	for _, finalizeEffect := range env.postFinalizeEffects {
@@ -224,7 +252,9 @@ func (env *Environment) setupAttackTables() {
 				attacker.AttackTables[idx] = make(map[proto.CastType]*AttackTable)
 			}
 
-			if attacker.Type == PlayerUnit {
+			if attacker.isDuelOpponent(defender) {
+				env.setupPvPAttackTables(attacker, defender, attacker.AttackTables[idx])
+			} else if attacker.Type == PlayerUnit {
 				character := env.Raid.GetPlayerFromUnit(attacker).GetCharacter()
 				weapons := []*Item{character.GetMHWeapon(), character.GetOHWeapon(), character.GetRangedWeapon()}
 
@@ -0,0 +1,102 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+type externalBuffConfig struct {
+	cooldown time.Duration
+	aura     func(character *Character, actionTag int32) *Aura
+}
+
+var externalBuffConfigs = map[proto.ExternalBuff]externalBuffConfig{
+	proto.ExternalBuff_ExternalBuffPowerInfusion: {
+		cooldown: PowerInfusionCD,
+		aura: func(character *Character, actionTag int32) *Aura {
+			return PowerInfusionAura(&character.Unit, actionTag)
+		},
+	},
+	proto.ExternalBuff_ExternalBuffInnervate: {
+		cooldown: InnervateCD,
+		aura:     InnervateAura,
+	},
+	proto.ExternalBuff_ExternalBuffManaTideTotem: {
+		cooldown: ManaTideTotemCD,
+		aura:     ManaTideTotemAura,
+	},
+}
+
+// How often casts waiting on a condition, or on another source's buff to fade, are rechecked.
+const externalBuffCheckPeriod = time.Millisecond * 250
+
+// Schedules the external buff casts of the raid, registering their auras on the targets while
+// the raid is initialized. Casts wait until their time, their condition and their cooldown,
+// and until the target no longer has the same buff from another source.
+func (env *Environment) registerExternalBuffCasts(casts []*proto.ExternalBuffCast) {
+	for i, cast := range casts {
+		config, ok := externalBuffConfigs[cast.Buff]
+		if !ok {
+			panic(fmt.Sprintf("External buff cast %d: unsupported buff %s", i, cast.Buff))
+		}
+
+		targetUnit := env.GetUnit(cast.Target, nil)
+		if targetUnit == nil || targetUnit.Type != PlayerUnit {
+			panic(fmt.Sprintf("External buff cast %d: target must be a player in the raid", i))
+		}
+		character := env.Raid.GetPlayerFromUnit(targetUnit).GetCharacter()
+		aura := config.aura(character, int32(i+1))
+
+		// Conditions are APL values of the target's rotation, which only exists once finalized.
+		var condition APLValue
+		if cast.Condition != nil {
+			env.RegisterPostFinalizeEffect(func() {
+				rotation := character.Rotation
+				if rotation == nil {
+					rotation = character.newAPLRotation(&proto.APLRotation{})
+				}
+				condition = rotation.coerceTo(rotation.NewAPLValue(cast.Condition), proto.APLValueType_ValueTypeBool)
+				if condition == nil {
+					panic(fmt.Sprintf("External buff cast %d: invalid condition", i))
+				}
+			})
+		}
+
+		startAt := DurationFromSeconds(cast.TimeSeconds)
+		repeat := cast.Repeat
+		character.RegisterResetEffect(func(sim *Simulation) {
+			readyAt := startAt
+			tryCast := func(sim *Simulation) bool {
+				if sim.CurrentTime < readyAt || character.HasActiveAuraWithTag(aura.Tag) {
+					return false
+				}
+				if condition != nil && !condition.GetBool(sim) {
+					return false
+				}
+				aura.Activate(sim)
+				readyAt = sim.CurrentTime + config.cooldown
+				return true
+			}
+
+			StartDelayedAction(sim, DelayedActionOptions{
+				DoAt: startAt,
+				OnAction: func(sim *Simulation) {
+					if tryCast(sim) && !repeat {
+						return
+					}
+					var pa *PendingAction
+					pa = StartPeriodicAction(sim, PeriodicActionOptions{
+						Period: externalBuffCheckPeriod,
+						OnAction: func(sim *Simulation) {
+							if tryCast(sim) && !repeat {
+								pa.Cancel(sim)
+							}
+						},
+					})
+				},
+			})
+		})
+	}
+}
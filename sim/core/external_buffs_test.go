@@ -0,0 +1,46 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+func TestExternalBuffCasts(t *testing.T) {
+	raid := SinglePlayerRaidProto(
+		fakeDuelist(`{"type":"TypeAPL","priorityList":[{"action":{"castSpell":{"spellId":{"spellId":1}}}}]}`),
+		&proto.PartyBuffs{}, &proto.RaidBuffs{}, &proto.Debuffs{})
+	self := &proto.UnitReference{Type: proto.UnitReference_Player, Index: 0}
+	raid.ExternalBuffCasts = []*proto.ExternalBuffCast{
+		{Buff: proto.ExternalBuff_ExternalBuffPowerInfusion, Target: self, TimeSeconds: 5},
+		// Cut short by the end of the fight.
+		{Buff: proto.ExternalBuff_ExternalBuffPowerInfusion, Target: self, TimeSeconds: 25},
+		{
+			Buff:        proto.ExternalBuff_ExternalBuffPowerInfusion,
+			Target:      self,
+			TimeSeconds: 5,
+			Condition:   &proto.APLValue{Value: &proto.APLValue_Const{Const: &proto.APLValueConst{Val: "false"}}},
+		},
+	}
+
+	result := RunRaidSim(&proto.RaidSimRequest{
+		Raid:       raid,
+		Encounter:  &proto.Encounter{Duration: 30, Targets: []*proto.Target{{}}},
+		SimOptions: &proto.SimOptions{Iterations: 1, IsTest: true},
+	})
+	if result.Error != nil {
+		t.Fatalf("Sim failed: %s", result.Error.Message)
+	}
+
+	uptimes := map[int32]float64{}
+	for _, aura := range result.RaidMetrics.Parties[0].Players[0].Auras {
+		if aura.Id.GetSpellId() == 10060 {
+			uptimes[aura.Id.Tag] = aura.UptimeSecondsAvg
+		}
+	}
+	for tag, expected := range map[int32]float64{1: 15, 2: 5, 3: 0} {
+		if !WithinToleranceFloat64(uptimes[tag], expected, 1e-6) {
+			t.Fatalf("Expected Power Infusion cast %d to be up for %fs, got %fs", tag, expected, uptimes[tag])
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+// How often health triggered movement events check the boss health.
+const movementEventHealthCheckPeriod = time.Millisecond * 100
+
+// Aura active while an encounter movement event makes the unit move. Casts and channels are
+// interrupted when it's gained, as only instant spells can be used while moving.
+func (unit *Unit) forcedMovementAura() *Aura {
+	move := unit.MovementHandler
+	if move.forcedMoveAura != nil {
+		return move.forcedMoveAura
+	}
+
+	move.forcedMoveAura = unit.RegisterAura(Aura{
+		Label:    "Forced Movement",
+		ActionID: ActionID{OtherID: proto.OtherAction_OtherActionMove, Tag: 1},
+		Duration: NeverExpires,
+		OnReset: func(aura *Aura, sim *Simulation) {
+			move.forced = false
+		},
+		OnGain: func(aura *Aura, sim *Simulation) {
+			move.forced = true
+			unit.interruptForMovement(sim)
+			unit.AutoAttacks.CancelAutoSwing(sim)
+		},
+		OnExpire: func(aura *Aura, sim *Simulation) {
+			move.forced = false
+			unit.Metrics.AddForcedMovementTime(sim.CurrentTime - aura.StartedAt())
+			if move.Moving {
+				return
+			}
+			unit.AutoAttacks.EnableAutoSwing(sim)
+
+			// Simulate the delay from starting attack
+			unit.AutoAttacks.DelayMeleeBy(sim, time.Millisecond*50)
+		},
+	})
+	return move.forcedMoveAura
+}
+
+func (unit *Unit) interruptForMovement(sim *Simulation) {
+	if unit.IsChanneling(sim) {
+		if sim.Log != nil {
+			unit.Log(sim, "Channel of %s interrupted by movement", unit.ChanneledDot.Spell.ActionID)
+		}
+		unit.ChanneledDot.Cancel(sim)
+		unit.Metrics.MovementInterrupts++
+	}
+
+	if !unit.IsCasting(sim) {
+		return
+	}
+	if sim.Log != nil {
+		unit.Log(sim, "Cast of %s interrupted by movement", unit.Hardcast.ActionID)
+	}
+	unit.cancelHardcast(sim)
+	unit.Metrics.MovementInterrupts++
+}
+
+func validateMovementEvent(event *proto.MovementEvent) error {
+	if event.DurationSeconds <= 0 {
+		return fmt.Errorf("duration must be positive")
+	}
+	if event.DurationVariationSeconds < 0 || event.DurationVariationSeconds >= event.DurationSeconds {
+		return fmt.Errorf("duration variation must be between 0 and the duration")
+	}
+	if event.StartSeconds < 0 {
+		return fmt.Errorf("start can't be negative")
+	}
+	switch event.Trigger {
+	case proto.MovementTrigger_MovementTriggerPeriodic, proto.MovementTrigger_MovementTriggerRandom:
+		if event.IntervalSeconds <= 0 {
+			return fmt.Errorf("interval must be positive")
+		}
+	case proto.MovementTrigger_MovementTriggerHealth:
+		if event.HealthPercent <= 0 || event.HealthPercent >= 100 {
+			return fmt.Errorf("health percent must be between 0 and 100")
+		}
+	default:
+		return fmt.Errorf("unknown trigger %s", event.Trigger)
+	}
+	return nil
+}
+
+// Schedules the movement events of the encounter. Events are driven by the primary target, so
+// all affected players move at the same time.
+func (env *Environment) registerMovementEvents(events []*proto.MovementEvent) {
+	if len(events) == 0 {
+		return
+	}
+
+	var players []*Unit
+	for _, party := range env.Raid.Parties {
+		for _, player := range party.Players {
+			unit := &player.GetCharacter().Unit
+			unit.forcedMovementAura()
+			players = append(players, unit)
+		}
+	}
+	primaryTarget := &env.Encounter.Targets[0].Unit
+
+	for i, event := range events {
+		if err := validateMovementEvent(event); err != nil {
+			panic(fmt.Sprintf("Movement event %d: %s", i+1, err))
+		}
+
+		label := fmt.Sprintf("Movement Event %d", i+1)
+		startAt := DurationFromSeconds(event.StartSeconds)
+		interval := DurationFromSeconds(event.IntervalSeconds)
+		duration := DurationFromSeconds(event.DurationSeconds)
+		variation := DurationFromSeconds(event.DurationVariationSeconds)
+
+		startMovement := func(sim *Simulation) {
+			moveDuration := duration
+			if variation > 0 {
+				moveDuration += time.Duration((2*sim.RandomFloat(label+" Duration") - 1) * float64(variation))
+			}
+
+			for _, unit := range players {
+				inMelee := unit.DistanceFromTarget <= MaxMeleeAttackRange
+				if (event.Affects == proto.MovementAffects_MovementAffectsMelee && !inMelee) ||
+					(event.Affects == proto.MovementAffects_MovementAffectsRanged && inMelee) {
+					continue
+				}
+
+				aura := unit.MovementHandler.forcedMoveAura
+				if aura.IsActive() && aura.ExpiresAt() >= sim.CurrentTime+moveDuration {
+					continue
+				}
+				aura.Duration = moveDuration
+				aura.Activate(sim)
+			}
+		}
+
+		primaryTarget.RegisterResetEffect(func(sim *Simulation) {
+			switch event.Trigger {
+			case proto.MovementTrigger_MovementTriggerPeriodic:
+				StartDelayedAction(sim, DelayedActionOptions{
+					DoAt: startAt,
+					OnAction: func(sim *Simulation) {
+						StartPeriodicAction(sim, PeriodicActionOptions{
+							Period:          interval,
+							TickImmediately: true,
+							OnAction:        startMovement,
+						})
+					},
+				})
+			case proto.MovementTrigger_MovementTriggerRandom:
+				var scheduleNext func(sim *Simulation, after time.Duration)
+				scheduleNext = func(sim *Simulation, after time.Duration) {
+					StartDelayedAction(sim, DelayedActionOptions{
+						DoAt: after + time.Duration(sim.RandomExpFloat(label)*float64(interval)),
+						OnAction: func(sim *Simulation) {
+							startMovement(sim)
+							scheduleNext(sim, sim.CurrentTime)
+						},
+					})
+				}
+				scheduleNext(sim, startAt)
+			case proto.MovementTrigger_MovementTriggerHealth:
+				var pa *PendingAction
+				pa = StartPeriodicAction(sim, PeriodicActionOptions{
+					Period: movementEventHealthCheckPeriod,
+					OnAction: func(sim *Simulation) {
+						if sim.CurrentTime >= startAt && sim.GetRemainingDurationPercent()*100 <= event.HealthPercent {
+							startMovement(sim)
+							pa.Cancel(sim)
+						}
+					},
+				})
+			}
+		})
+	}
+}
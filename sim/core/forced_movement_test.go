@@ -0,0 +1,105 @@
+package core
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+func TestValidateMovementEvent(t *testing.T) {
+	valid := []*proto.MovementEvent{
+		{Trigger: proto.MovementTrigger_MovementTriggerPeriodic, IntervalSeconds: 30, DurationSeconds: 3},
+		{Trigger: proto.MovementTrigger_MovementTriggerRandom, IntervalSeconds: 20, DurationSeconds: 2, DurationVariationSeconds: 1},
+		{Trigger: proto.MovementTrigger_MovementTriggerHealth, HealthPercent: 30, DurationSeconds: 5},
+	}
+	for _, event := range valid {
+		if err := validateMovementEvent(event); err != nil {
+			t.Fatalf("Expected %v to be valid, got %s", event, err)
+		}
+	}
+
+	invalid := []*proto.MovementEvent{
+		{Trigger: proto.MovementTrigger_MovementTriggerPeriodic, DurationSeconds: 3},
+		{Trigger: proto.MovementTrigger_MovementTriggerRandom, IntervalSeconds: 20},
+		{Trigger: proto.MovementTrigger_MovementTriggerHealth, HealthPercent: 100, DurationSeconds: 5},
+		{Trigger: proto.MovementTrigger_MovementTriggerPeriodic, IntervalSeconds: 30, DurationSeconds: 3, DurationVariationSeconds: 3},
+	}
+	for _, event := range invalid {
+		if err := validateMovementEvent(event); err == nil {
+			t.Fatalf("Expected %v to be invalid", event)
+		}
+	}
+}
+
+func TestForcedMovementInterruptsCasts(t *testing.T) {
+	raid := SinglePlayerRaidProto(
+		fakeDuelist(`{"type":"TypeAPL","priorityList":[{"action":{"castSpell":{"spellId":{"spellId":1}}}}]}`),
+		&proto.PartyBuffs{}, &proto.RaidBuffs{}, &proto.Debuffs{})
+
+	// Moves at 1s, 12s and 23s, each time 1s into a 2s cast.
+	result := RunRaidSim(&proto.RaidSimRequest{
+		Raid: raid,
+		Encounter: &proto.Encounter{
+			Duration: 30,
+			Targets:  []*proto.Target{{}},
+			MovementEvents: []*proto.MovementEvent{
+				{Trigger: proto.MovementTrigger_MovementTriggerPeriodic, StartSeconds: 1, IntervalSeconds: 11, DurationSeconds: 2},
+			},
+		},
+		SimOptions: &proto.SimOptions{Iterations: 1, IsTest: true, Debug: true},
+	})
+	if result.Error != nil {
+		t.Fatalf("Sim failed: %s", result.Error.Message)
+	}
+
+	metrics := result.RaidMetrics.Parties[0].Players[0]
+	if metrics.MovementInterruptsAvg != 3 {
+		t.Fatalf("Expected 3 casts interrupted by movement, got %f", metrics.MovementInterruptsAvg)
+	}
+	if !WithinToleranceFloat64(metrics.SecondsForcedMovementAvg, 6, 1e-6) {
+		t.Fatalf("Expected 6s of forced movement, got %f", metrics.SecondsForcedMovementAvg)
+	}
+
+	var moveUptime float64
+	for _, aura := range metrics.Auras {
+		if aura.Id.GetOtherId() == proto.OtherAction_OtherActionMove && aura.Id.Tag == 1 {
+			moveUptime = aura.UptimeSecondsAvg
+		}
+	}
+	if !WithinToleranceFloat64(moveUptime, 6, 1e-6) {
+		t.Fatalf("Expected the forced movement aura on the timeline for 6s, got %f", moveUptime)
+	}
+	if count := strings.Count(result.Logs, "interrupted by movement"); count != 3 {
+		t.Fatalf("Expected 3 movement interrupts in the log, got %d", count)
+	}
+}
+
+func TestForcedMovementInterruptsExplosives(t *testing.T) {
+	player := fakeDuelist(`{"type":"TypeAPL","priorityList":[{"action":{"castSpell":{"spellId":{"itemId":233986}}}}]}`)
+	player.Consumes.FillerExplosive = proto.Explosive_ExplosiveObsidianBomb
+
+	// Moves half way through the 1s cast of the bomb.
+	result := RunRaidSim(&proto.RaidSimRequest{
+		Raid: SinglePlayerRaidProto(player, &proto.PartyBuffs{}, &proto.RaidBuffs{}, &proto.Debuffs{}),
+		Encounter: &proto.Encounter{
+			Duration: 30,
+			Targets:  []*proto.Target{{}},
+			MovementEvents: []*proto.MovementEvent{
+				{Trigger: proto.MovementTrigger_MovementTriggerPeriodic, StartSeconds: 0.5, IntervalSeconds: 60, DurationSeconds: 2},
+			},
+		},
+		SimOptions: &proto.SimOptions{Iterations: 1, IsTest: true, Debug: true},
+	})
+	if result.Error != nil {
+		t.Fatalf("Sim failed: %s", result.Error.Message)
+	}
+
+	if metrics := result.RaidMetrics.Parties[0].Players[0]; metrics.MovementInterruptsAvg != 1 {
+		t.Fatalf("Expected the bomb to be interrupted once, got %f", metrics.MovementInterruptsAvg)
+	}
+	// The interrupted cast doesn't start the shared explosive cooldown, so the bomb is thrown once the movement ends.
+	if !strings.Contains(result.Logs, "[3.50] [duelist (#1)] Completed cast {ItemID: 233986}") {
+		t.Fatalf("Expected the bomb to be thrown after the movement, got logs:\n%s", result.Logs)
+	}
+}
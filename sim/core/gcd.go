@@ -33,6 +33,24 @@ func (unit *Unit) newHardcastAction(sim *Simulation) {
 	sim.AddPendingAction(unit.hardcastAction)
 }
 
+// Stops the current hardcast without completing it, and frees the GCD. Cooldowns which the cast
+// started are reset, as the spell was never cast.
+func (unit *Unit) cancelHardcast(sim *Simulation) {
+	if spell := unit.GetSpell(unit.Hardcast.ActionID); spell != nil {
+		if spell.CD.Timer != nil {
+			spell.CD.Reset()
+		}
+		if spell.SharedCD.Timer != nil {
+			spell.SharedCD.Reset()
+		}
+	}
+	if unit.hardcastAction != nil && !unit.hardcastAction.consumed {
+		unit.hardcastAction.Cancel(sim)
+	}
+	unit.Hardcast.Expires = startingCDTime
+	unit.SetGCDTimer(sim, sim.CurrentTime)
+}
+
 func (unit *Unit) NextGCDAt() time.Duration {
 	return unit.gcdAction.NextActionAt
 }
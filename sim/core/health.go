@@ -15,6 +15,24 @@ type healthBar struct {
 	DamageTakenHealthMetrics *ResourceMetrics
 }
 
+// Resolves the unit a heal lands on. Heals cast at an enemy, e.g. by an APL action without a
+// target, go to the player the primary target is attacking, then to the first target dummy, and
+// finally to the healer itself.
+func (unit *Unit) HealTarget(sim *Simulation, target *Unit) *Unit {
+	if target != nil && target.Type != EnemyUnit {
+		return target
+	}
+	if len(sim.Encounter.TargetUnits) > 0 {
+		if tank := sim.Encounter.TargetUnits[0].CurrentTarget; tank != nil && tank.Type == PlayerUnit {
+			return tank
+		}
+	}
+	if dummy := unit.Env.Raid.GetFirstTargetDummy(); dummy != nil {
+		return &dummy.Unit
+	}
+	return unit
+}
+
 func (unit *Unit) EnableHealthBar() {
 	unit.healthBar = healthBar{
 		unit:                     unit,
@@ -20,6 +20,10 @@ var itemEffects = map[int32]ApplyEffect{}
 var weaponEffects = map[int32]ApplyEffect{}
 var enchantEffects = map[int32]ApplyEffect{}
 
+// Items whose effects are implemented directly in class code, e.g. by checking
+// the equipped relic, rather than through NewItemEffect.
+var implicitItemEffects = map[int32]bool{}
+
 // IDs of item effects which should be used for tests.
 var itemEffectsForTest []int32
 
@@ -44,6 +48,44 @@ func HasEnchantEffect(id int32) bool {
 	return ok
 }
 
+// Marks items whose effects are handled by class code instead of a registered ApplyEffect,
+// so they aren't reported as unimplemented.
+func NewImplicitItemEffects(ids ...int32) {
+	for _, id := range ids {
+		implicitItemEffects[id] = true
+	}
+}
+
+// Returns whether the item has a registered or implicit effect implementation.
+func IsItemEffectImplemented(id int32) bool {
+	return HasItemEffect(id) || implicitItemEffects[id]
+}
+
+// Returns whether the enchant does anything in the sim, either through stats or a registered effect.
+func IsEnchantImplemented(enchant Enchant) bool {
+	return !enchant.Stats.Equals(stats.Stats{}) || HasEnchantEffect(enchant.EffectID) || HasWeaponEffect(enchant.EffectID)
+}
+
+// Returns whether the item's tooltip lists an effect which is not implemented,
+// meaning it will sim as a plain stat stick.
+func (item *Item) HasUnimplementedEffect() bool {
+	return item.ID != 0 && item.HasEffect && !IsItemEffectImplemented(item.ID)
+}
+
+// Returns a warning for every equipped item or enchant with an unimplemented effect.
+func (equipment *Equipment) UnimplementedEffectWarnings() []string {
+	var warnings []string
+	for slot, item := range equipment {
+		if item.HasUnimplementedEffect() {
+			warnings = append(warnings, fmt.Sprintf("%s (%d) in %s has an effect which is not implemented in the sim.", item.Name, item.ID, proto.ItemSlot(slot)))
+		}
+		if item.Enchant.EffectID != 0 && !IsEnchantImplemented(item.Enchant) {
+			warnings = append(warnings, fmt.Sprintf("Enchant %d on %s has no effect in the sim.", item.Enchant.EffectID, proto.ItemSlot(slot)))
+		}
+	}
+	return warnings
+}
+
 // Registers an ApplyEffect function which will be called before the Sim
 // starts, for any Agent that is wearing the item.
 func NewItemEffect(id int32, itemEffect ApplyEffect) {
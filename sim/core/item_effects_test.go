@@ -0,0 +1,31 @@
+package core
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/wowsims/sod/sim/core/proto"
+	"github.com/wowsims/sod/sim/core/stats"
+)
+
+func TestUnimplementedEffectWarnings(t *testing.T) {
+	const implementedID, unimplementedID, statStickID = 9000001, 9000002, 9000003
+	NewImplicitItemEffects(implementedID)
+
+	var equipment Equipment
+	equipment[proto.ItemSlot_ItemSlotTrinket1] = Item{ID: implementedID, Name: "Implemented", HasEffect: true}
+	equipment[proto.ItemSlot_ItemSlotTrinket2] = Item{ID: unimplementedID, Name: "Unimplemented", HasEffect: true}
+	equipment[proto.ItemSlot_ItemSlotChest] = Item{ID: statStickID, Name: "Stat Stick", Enchant: Enchant{EffectID: 9000004}}
+	equipment[proto.ItemSlot_ItemSlotLegs] = Item{ID: statStickID, Name: "Stat Stick", Enchant: Enchant{EffectID: 9000005, Stats: stats.Stats{stats.Stamina: 10}}}
+
+	warnings := equipment.UnimplementedEffectWarnings()
+	if len(warnings) != 2 {
+		t.Fatalf("Expected 2 warnings, got %q", warnings)
+	}
+	if !strings.Contains(warnings[0], "Enchant 9000004") || !strings.Contains(warnings[0], proto.ItemSlot_ItemSlotChest.String()) {
+		t.Fatalf("Expected a warning for the chest enchant without effect, got %q", warnings[0])
+	}
+	if !strings.Contains(warnings[1], "Unimplemented (9000002)") || !strings.Contains(warnings[1], proto.ItemSlot_ItemSlotTrinket2.String()) {
+		t.Fatalf("Expected a warning for the unimplemented trinket, got %q", warnings[1])
+	}
+}
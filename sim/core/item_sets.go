@@ -69,6 +69,13 @@ func NewItemSet(set ItemSet) *ItemSet {
 	return &set
 }
 
+// Returns whether an ItemSet with the given ID or name has been registered.
+func HasItemSet(id int32, name string) bool {
+	return slices.ContainsFunc(sets, func(set *ItemSet) bool {
+		return (id > 0 && set.ID == id) || set.Name == name || (set.AlternativeName != "" && set.AlternativeName == name)
+	})
+}
+
 func (character *Character) HasSetBonus(set *ItemSet, numItems int32) bool {
 	if character.Env != nil && character.Env.IsFinalized() {
 		panic("HasSetBonus is very slow and should never be called after finalization. Try caching the value during construction instead!")
@@ -0,0 +1,61 @@
+package core
+
+import (
+	"time"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+type Latency struct {
+	// One-way latency between client and server.
+	Mean  time.Duration
+	Stdev time.Duration
+
+	// How early the client accepts the next cast while the GCD or current cast is still running.
+	SpellQueueWindow time.Duration
+}
+
+func LatencyFromProto(config *proto.NetworkLatency) Latency {
+	return Latency{
+		Mean:             max(0, time.Duration(config.GetMeanMs())*time.Millisecond),
+		Stdev:            max(0, time.Duration(config.GetStdevMs())*time.Millisecond),
+		SpellQueueWindow: max(0, time.Duration(config.GetSpellQueueWindowMs())*time.Millisecond),
+	}
+}
+
+func (latency Latency) IsZero() bool {
+	return latency.Mean == 0 && latency.Stdev == 0
+}
+
+// Draws the latency of a single input.
+func (unit *Unit) sampleLatency(sim *Simulation) time.Duration {
+	latency := unit.Latency
+	if latency.Stdev == 0 {
+		return latency.Mean
+	}
+	return max(0, latency.Mean+time.Duration(sim.RandomNormFloat("Latency")*float64(latency.Stdev)))
+}
+
+// Delay between the GCD or a cast finishing and the next cast starting on the server. Inputs
+// sent within the spell queue window reach the server early, so only latency beyond the window
+// is lost.
+func (unit *Unit) nextCastDelay(sim *Simulation) time.Duration {
+	if unit.Latency.IsZero() {
+		return 0
+	}
+	return max(0, unit.sampleLatency(sim)-unit.Latency.SpellQueueWindow)
+}
+
+// Reaction time to in-game events, including the time it takes for them to reach the client.
+func (unit *Unit) ReactionDelay() time.Duration {
+	return unit.ReactionTime + unit.Latency.Mean
+}
+
+// Whether an on-next-swing ability queued at queuedAt has reached the server, so it can replace
+// the current swing. Queued too late, it replaces the swing after.
+func (unit *Unit) HasQueueLanded(sim *Simulation, queuedAt time.Duration) bool {
+	if unit.Latency.IsZero() {
+		return true
+	}
+	return sim.CurrentTime-queuedAt >= unit.sampleLatency(sim)
+}
@@ -0,0 +1,37 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+func TestNextCastDelay(t *testing.T) {
+	unit := &Unit{Latency: LatencyFromProto(&proto.NetworkLatency{MeanMs: 150, SpellQueueWindowMs: 100})}
+	if delay := unit.nextCastDelay(nil); delay != 50*time.Millisecond {
+		t.Fatalf("Expected 50ms delay beyond the spell queue window, got %s", delay)
+	}
+
+	unit.Latency.SpellQueueWindow = 400 * time.Millisecond
+	if delay := unit.nextCastDelay(nil); delay != 0 {
+		t.Fatalf("Expected latency within the spell queue window to be hidden, got %s", delay)
+	}
+
+	unit.Latency = LatencyFromProto(&proto.NetworkLatency{MeanMs: -20})
+	if !unit.Latency.IsZero() {
+		t.Fatalf("Expected negative latency to be ignored")
+	}
+}
+
+func TestHasQueueLanded(t *testing.T) {
+	sim := &Simulation{CurrentTime: time.Second}
+	unit := &Unit{Latency: Latency{Mean: 100 * time.Millisecond}}
+
+	if unit.HasQueueLanded(sim, sim.CurrentTime-50*time.Millisecond) {
+		t.Fatalf("Expected a queue 50ms before the swing to miss it with 100ms latency")
+	}
+	if !unit.HasQueueLanded(sim, sim.CurrentTime-150*time.Millisecond) {
+		t.Fatalf("Expected a queue 150ms before the swing to land with 100ms latency")
+	}
+}
@@ -0,0 +1,96 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+// A level cap at which Season of Discovery content is played.
+type LevelBracket struct {
+	Level int32
+
+	// First phase in which the bracket was the level cap.
+	Phase int32
+
+	// Level of raid bosses in this bracket, used for targets without an explicit level.
+	TargetLevel int32
+}
+
+var LevelBrackets = []LevelBracket{
+	{Level: 25, Phase: 1, TargetLevel: 27},
+	{Level: 40, Phase: 2, TargetLevel: 42},
+	{Level: 50, Phase: 3, TargetLevel: 52},
+	{Level: CharacterMaxLevel, Phase: 4, TargetLevel: CharacterMaxLevel + 3},
+}
+
+func MaxLevelBracket() LevelBracket {
+	return LevelBrackets[len(LevelBrackets)-1]
+}
+
+func GetLevelBracket(level int32) (LevelBracket, error) {
+	idx := slices.IndexFunc(LevelBrackets, func(bracket LevelBracket) bool { return bracket.Level == level })
+	if idx == -1 {
+		return LevelBracket{}, fmt.Errorf("level %d is not a supported level bracket", level)
+	}
+	return LevelBrackets[idx], nil
+}
+
+// Returns the level bracket shared by all players in the raid.
+// Players without a level are ignored, and a raid without any levelled players uses the max bracket.
+// Mixing brackets, unsupported levels and items above a player's level are reported as errors.
+func RaidLevelBracket(raid *proto.Raid) (LevelBracket, error) {
+	var bracket *LevelBracket
+	var errs []error
+
+	for _, party := range raid.Parties {
+		if party == nil {
+			continue
+		}
+		for _, player := range party.Players {
+			if player == nil || player.Level == 0 {
+				continue
+			}
+
+			playerBracket, err := GetLevelBracket(player.Level)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", player.Name, err))
+				continue
+			}
+			if bracket == nil {
+				bracket = &playerBracket
+			} else if bracket.Level != playerBracket.Level {
+				errs = append(errs, fmt.Errorf("%s: level %d does not match the raid's level bracket %d", player.Name, player.Level, bracket.Level))
+			}
+
+			errs = append(errs, validateItemLevels(player)...)
+		}
+	}
+
+	if len(errs) > 0 {
+		return LevelBracket{}, errors.Join(errs...)
+	}
+	if bracket == nil {
+		return MaxLevelBracket(), nil
+	}
+	return *bracket, nil
+}
+
+func validateItemLevels(player *proto.Player) []error {
+	if player.Equipment == nil {
+		return nil
+	}
+
+	var errs []error
+	for _, itemSpec := range player.Equipment.Items {
+		if itemSpec == nil {
+			continue
+		}
+		if item, ok := ItemsByID[itemSpec.Id]; ok && item.RequiresLevel > player.Level {
+			errs = append(errs, fmt.Errorf("%s: %s (%d) requires level %d", player.Name, item.Name, item.ID, item.RequiresLevel))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,42 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+func levelBracketTestRaid(levels ...int32) *proto.Raid {
+	party := &proto.Party{}
+	for _, level := range levels {
+		party.Players = append(party.Players, &proto.Player{Level: level, Equipment: &proto.EquipmentSpec{}})
+	}
+	return &proto.Raid{Parties: []*proto.Party{party}}
+}
+
+func TestRaidLevelBracket(t *testing.T) {
+	bracket, err := RaidLevelBracket(levelBracketTestRaid(40, 40))
+	if err != nil || bracket.Level != 40 || bracket.TargetLevel != 42 {
+		t.Fatalf("Expected level 40 bracket, got %v, %v", bracket, err)
+	}
+
+	bracket, err = RaidLevelBracket(levelBracketTestRaid(0))
+	if err != nil || bracket.Level != CharacterMaxLevel {
+		t.Fatalf("Expected max bracket for unlevelled raid, got %v, %v", bracket, err)
+	}
+
+	if _, err := RaidLevelBracket(levelBracketTestRaid(25, 50)); err == nil {
+		t.Fatalf("Expected error for mixed level brackets")
+	}
+
+	if _, err := RaidLevelBracket(levelBracketTestRaid(33)); err == nil {
+		t.Fatalf("Expected error for unsupported level")
+	}
+
+	addToDatabase(&proto.SimDatabase{Items: []*proto.SimItem{{Id: 900101, Name: "Level 40 Helm", RequiresLevel: 40, Type: proto.ItemType_ItemTypeHead}}})
+	raid := levelBracketTestRaid(25)
+	raid.Parties[0].Players[0].Equipment.Items = []*proto.ItemSpec{{Id: 900101}}
+	if _, err := RaidLevelBracket(raid); err == nil {
+		t.Fatalf("Expected error for item above the player's level")
+	}
+}
@@ -2,6 +2,7 @@ package core
 
 import (
 	"math"
+	"slices"
 	"time"
 
 	"github.com/wowsims/sod/sim/core/proto"
@@ -18,12 +19,13 @@ type DistributionMetrics struct {
 
 	// Aggregate values. These are updated after each iteration.
 	aggregator
-	max     float64
-	min     float64
-	maxSeed int64
-	minSeed int64
-	hist    map[int32]int32 // rounded DPS to count
-	sample  []float64
+	max        float64
+	min        float64
+	maxSeed    int64
+	minSeed    int64
+	hist       map[int32]int32 // rounded value to count
+	bucketSize int32           // width of the hist buckets
+	sample     []float64
 }
 
 func (distMetrics *DistributionMetrics) reset() {
@@ -33,7 +35,7 @@ func (distMetrics *DistributionMetrics) reset() {
 // This should be called when a Sim iteration is complete.
 func (distMetrics *DistributionMetrics) doneIteration(sim *Simulation) {
 	dps := distMetrics.Total / sim.Duration.Seconds()
-	distMetrics.add(dps)
+	distMetrics.record(sim, dps)
 
 	if sim.Options.SaveAllValues {
 		if cap(distMetrics.sample) < int(sim.Options.Iterations) {
@@ -41,32 +43,51 @@ func (distMetrics *DistributionMetrics) doneIteration(sim *Simulation) {
 		}
 		distMetrics.sample = append(distMetrics.sample, dps)
 	}
+}
+
+// Adds a single value to the aggregates, e.g. the DPS of an iteration or the damage of a hit.
+func (distMetrics *DistributionMetrics) record(sim *Simulation, value float64) {
+	distMetrics.add(value)
 
-	if dps > distMetrics.max {
-		distMetrics.max = dps
+	if value > distMetrics.max {
+		distMetrics.max = value
 		distMetrics.maxSeed = sim.rand.GetSeed()
 	}
-	if dps <= distMetrics.min || distMetrics.min < 0 {
-		distMetrics.min = dps
+	if value <= distMetrics.min || distMetrics.min < 0 {
+		distMetrics.min = value
 		distMetrics.minSeed = sim.rand.GetSeed()
 	}
 
-	dpsRounded := int32(math.Round(dps/10) * 10)
-	distMetrics.hist[dpsRounded]++
+	bucketSize := float64(distMetrics.bucketSize)
+	distMetrics.hist[int32(math.Round(value/bucketSize)*bucketSize)]++
 }
 
 func (distMetrics *DistributionMetrics) ToProto() *proto.DistributionMetrics {
+	if distMetrics.n == 0 {
+		// E.g. the damage per hit of an action that never landed.
+		return &proto.DistributionMetrics{
+			Hist:           distMetrics.hist,
+			HistBucketSize: distMetrics.bucketSize,
+			AggregatorData: &proto.AggregatorData{},
+		}
+	}
+
 	mean, stdev := distMetrics.meanAndStdDev()
+	p := distributionPercentiles(distMetrics.sample, distMetrics.hist)
 
 	return &proto.DistributionMetrics{
-		Avg:       mean,
-		Stdev:     stdev,
-		Max:       distMetrics.max,
-		Min:       distMetrics.min,
-		MaxSeed:   distMetrics.maxSeed,
-		MinSeed:   distMetrics.minSeed,
-		Hist:      distMetrics.hist,
-		AllValues: distMetrics.sample,
+		Avg:            mean,
+		Stdev:          stdev,
+		Max:            distMetrics.max,
+		Min:            distMetrics.min,
+		MaxSeed:        distMetrics.maxSeed,
+		MinSeed:        distMetrics.minSeed,
+		Hist:           distMetrics.hist,
+		HistBucketSize: distMetrics.bucketSize,
+		AllValues:      distMetrics.sample,
+		P5:             p[0],
+		P50:            p[1],
+		P95:            p[2],
 
 		AggregatorData: &proto.AggregatorData{
 			N:     int32(distMetrics.n),
@@ -76,12 +97,113 @@ func (distMetrics *DistributionMetrics) ToProto() *proto.DistributionMetrics {
 }
 
 func NewDistributionMetrics() DistributionMetrics {
+	return newBucketedDistributionMetrics(10)
+}
+
+func newBucketedDistributionMetrics(bucketSize int32) DistributionMetrics {
 	return DistributionMetrics{
-		hist: make(map[int32]int32),
-		min:  -1,
+		hist:       make(map[int32]int32),
+		bucketSize: bucketSize,
+		min:        -1,
+	}
+}
+
+// The percentiles reported for each distribution, see distributionPercentiles.
+var reportedPercentiles = [3]float64{0.05, 0.5, 0.95}
+
+// Returns the reportedPercentiles by nearest rank. Exact when values are given, otherwise
+// they're read from the hist and are only as precise as its buckets.
+func distributionPercentiles(values []float64, hist map[int32]int32) [3]float64 {
+	var result [3]float64
+
+	if len(values) > 0 {
+		sorted := slices.Clone(values)
+		slices.Sort(sorted)
+		for i, p := range reportedPercentiles {
+			result[i] = sorted[nearestRank(p, len(sorted))]
+		}
+		return result
+	}
+
+	n := 0
+	buckets := make([]int32, 0, len(hist))
+	for bucket, count := range hist {
+		buckets = append(buckets, bucket)
+		n += int(count)
+	}
+	if n == 0 {
+		return result
+	}
+	slices.Sort(buckets)
+
+	idx, below := 0, 0
+	for i, p := range reportedPercentiles {
+		r := nearestRank(p, n)
+		for below+int(hist[buckets[idx]]) <= r {
+			below += int(hist[buckets[idx]])
+			idx++
+		}
+		result[i] = float64(buckets[idx])
+	}
+	return result
+}
+
+// Index of the p-th percentile among n sorted values.
+func nearestRank(p float64, n int) int {
+	return max(0, min(n-1, int(math.Ceil(p*float64(n)))-1))
+}
+
+// Distributions for a single action, only collected with SimOptions.SaveActionDistributions.
+// Spells sharing an ActionID share these.
+type actionDistributions struct {
+	damagePerHit  DistributionMetrics
+	damagePerCast DistributionMetrics
+	dps           DistributionMetrics
+
+	// Damage done since the last cast, which is attributed to that cast once the next
+	// one starts or the iteration ends.
+	castDamage float64
+	castOpen   bool
+}
+
+func (ad *actionDistributions) recordCast(sim *Simulation) {
+	ad.closeCast(sim)
+	ad.castOpen = true
+}
+
+func (ad *actionDistributions) recordDamage(sim *Simulation, result *SpellResult) {
+	ad.dps.Total += result.Damage
+	ad.castDamage += result.Damage
+	if result.Landed() {
+		ad.damagePerHit.record(sim, result.Damage)
 	}
 }
 
+func (ad *actionDistributions) closeCast(sim *Simulation) {
+	if ad.castOpen {
+		ad.damagePerCast.record(sim, ad.castDamage)
+	}
+	ad.castDamage = 0
+	ad.castOpen = false
+}
+
+func (ad *actionDistributions) reset() {
+	ad.dps.reset()
+	ad.castDamage = 0
+	ad.castOpen = false
+}
+
+func (ad *actionDistributions) doneIteration(sim *Simulation) {
+	ad.closeCast(sim)
+	ad.dps.doneIteration(sim)
+}
+
+func (ad *actionDistributions) applyToProto(actionMetrics *proto.ActionMetrics) {
+	actionMetrics.DamagePerHit = ad.damagePerHit.ToProto()
+	actionMetrics.DamagePerCast = ad.damagePerCast.ToProto()
+	actionMetrics.Dps = ad.dps.ToProto()
+}
+
 type UnitMetrics struct {
 	dps    DistributionMetrics
 	dpasp  DistributionMetrics
@@ -98,10 +220,15 @@ type UnitMetrics struct {
 	CharacterIterationMetrics
 
 	// Aggregate values. These are updated after each iteration.
-	numItersDead int32
-	oomTimeSum   float64
-	actions      map[ActionID]*ActionMetrics
-	resources    []*ResourceMetrics
+	numItersDead          int32
+	oomTimeSum            float64
+	forcedMovementTimeSum float64
+	movementInterruptsSum int32
+	manaSpentSum          float64
+	healingSum            float64
+	actions               map[ActionID]*ActionMetrics
+	distributions         map[ActionID]*actionDistributions
+	resources             []*ResourceMetrics
 }
 
 // Metrics for the current iteration, for 1 agent. Keep this as a separate
@@ -115,6 +242,9 @@ type CharacterIterationMetrics struct {
 
 	OOMTime time.Duration // time spent not casting and waiting for regen.
 
+	ForcedMovementTime time.Duration // time spent moving because of encounter movement events.
+	MovementInterrupts int32         // casts and channels interrupted by encounter movement events.
+
 	FirstOOMTimestamp time.Duration // Timestamp at which unit first went OOM.
 }
 
@@ -276,6 +406,24 @@ func NewUnitMetrics() UnitMetrics {
 	}
 }
 
+// Returns the distributions for an action, shared by all spells with that ActionID.
+func (unitMetrics *UnitMetrics) actionDistributions(actionID ActionID) *actionDistributions {
+	if unitMetrics.distributions == nil {
+		unitMetrics.distributions = make(map[ActionID]*actionDistributions)
+	}
+	ad, ok := unitMetrics.distributions[actionID]
+	if !ok {
+		// Damage values are too small for the 10 wide buckets used for unit DPS.
+		ad = &actionDistributions{
+			damagePerHit:  newBucketedDistributionMetrics(1),
+			damagePerCast: newBucketedDistributionMetrics(1),
+			dps:           newBucketedDistributionMetrics(1),
+		}
+		unitMetrics.distributions[actionID] = ad
+	}
+	return ad
+}
+
 type ResourceMetrics struct {
 	ActionID ActionID
 	Type     proto.ResourceType
@@ -448,6 +596,10 @@ func (unitMetrics *UnitMetrics) AddOOMTime(sim *Simulation, dur time.Duration) {
 		unitMetrics.MarkOOM(sim)
 	}
 }
+func (unitMetrics *UnitMetrics) AddForcedMovementTime(dur time.Duration) {
+	unitMetrics.CharacterIterationMetrics.ForcedMovementTime += dur
+}
+
 func (unitMetrics *UnitMetrics) MarkOOM(sim *Simulation) {
 	if !unitMetrics.WentOOM {
 		unitMetrics.WentOOM = true
@@ -474,6 +626,9 @@ func (unitMetrics *UnitMetrics) reset() {
 	for _, resourceMetrics := range unitMetrics.resources {
 		resourceMetrics.reset()
 	}
+	for _, ad := range unitMetrics.distributions {
+		ad.reset()
+	}
 }
 
 // This should be called when a Sim iteration is complete.
@@ -511,10 +666,17 @@ func (unitMetrics *UnitMetrics) doneIteration(unit *Unit, sim *Simulation) {
 	unitMetrics.threat.doneIteration(sim)
 	unitMetrics.dtps.doneIteration(sim)
 	unitMetrics.tmi.doneIteration(sim)
+	unitMetrics.healingSum += unitMetrics.hps.Total
 	unitMetrics.hps.doneIteration(sim)
 	unitMetrics.tto.doneIteration(sim)
+	for _, ad := range unitMetrics.distributions {
+		ad.doneIteration(sim)
+	}
 
 	unitMetrics.oomTimeSum += unitMetrics.OOMTime.Seconds()
+	unitMetrics.forcedMovementTimeSum += unitMetrics.ForcedMovementTime.Seconds()
+	unitMetrics.movementInterruptsSum += unitMetrics.MovementInterrupts
+	unitMetrics.manaSpentSum += unitMetrics.ManaSpent
 	if unitMetrics.Died {
 		unitMetrics.numItersDead++
 	}
@@ -604,11 +766,23 @@ func (unitMetrics *UnitMetrics) ToProto() *proto.UnitMetrics {
 		Tto:           unitMetrics.tto.ToProto(),
 		SecondsOomAvg: unitMetrics.oomTimeSum / n,
 		ChanceOfDeath: float64(unitMetrics.numItersDead) / n,
+
+		SecondsForcedMovementAvg: unitMetrics.forcedMovementTimeSum / n,
+		MovementInterruptsAvg:    float64(unitMetrics.movementInterruptsSum) / n,
+
+		ManaSpentAvg: unitMetrics.manaSpentSum / n,
+	}
+	if unitMetrics.manaSpentSum > 0 {
+		protoMetrics.HealingPerMana = unitMetrics.healingSum / unitMetrics.manaSpentSum
 	}
 
 	protoMetrics.Actions = make([]*proto.ActionMetrics, 0, len(unitMetrics.actions))
 	for actionID, action := range unitMetrics.actions {
-		protoMetrics.Actions = append(protoMetrics.Actions, action.ToProto(actionID))
+		actionProto := action.ToProto(actionID)
+		if ad, ok := unitMetrics.distributions[actionID]; ok {
+			ad.applyToProto(actionProto)
+		}
+		protoMetrics.Actions = append(protoMetrics.Actions, actionProto)
 	}
 
 	protoMetrics.Resources = make([]*proto.ResourceMetrics, 0, len(unitMetrics.resources))
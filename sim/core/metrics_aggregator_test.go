@@ -0,0 +1,85 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+func TestDistributionPercentiles(t *testing.T) {
+	values := make([]float64, 100)
+	for i := range values {
+		values[len(values)-1-i] = float64(i + 1)
+	}
+	if p := distributionPercentiles(values, nil); p != [3]float64{5, 50, 95} {
+		t.Fatalf("Expected percentiles 5, 50 and 95, got %v", p)
+	}
+
+	// 10% at 100, 80% at 200 and 10% at 300.
+	hist := map[int32]int32{100: 10, 200: 80, 300: 10}
+	if p := distributionPercentiles(nil, hist); p != [3]float64{100, 200, 300} {
+		t.Fatalf("Expected percentiles 100, 200 and 300, got %v", p)
+	}
+
+	if p := distributionPercentiles(nil, map[int32]int32{}); p != [3]float64{} {
+		t.Fatalf("Expected zero percentiles for an empty distribution, got %v", p)
+	}
+}
+
+func TestCombineCountedDistMetrics(t *testing.T) {
+	rsrc := &raidSimResultCombiner{}
+	base := rsrc.newDistMetrics()
+
+	// 30 hits averaging 100 and 10 hits averaging 500.
+	rsrc.combineCountedDistMetrics(base, &proto.DistributionMetrics{
+		Avg:            100,
+		Min:            100,
+		Max:            100,
+		Hist:           map[int32]int32{100: 30},
+		AggregatorData: &proto.AggregatorData{N: 30, SumSq: 30 * 100 * 100},
+	})
+	rsrc.combineCountedDistMetrics(base, &proto.DistributionMetrics{
+		Avg:            500,
+		Min:            500,
+		Max:            500,
+		Hist:           map[int32]int32{500: 10},
+		AggregatorData: &proto.AggregatorData{N: 10, SumSq: 10 * 500 * 500},
+	})
+	// A shard in which the action never hit.
+	rsrc.combineCountedDistMetrics(base, &proto.DistributionMetrics{AggregatorData: &proto.AggregatorData{}})
+	rsrc.finishDistMetrics(base)
+
+	if base.Min != 100 || base.Max != 500 {
+		t.Fatalf("Expected min of 100 and max of 500, got %f and %f", base.Min, base.Max)
+	}
+	if !WithinToleranceFloat64(base.Avg, 200, 1e-9) {
+		t.Fatalf("Expected the average to be weighted by hits, got %f", base.Avg)
+	}
+	if base.P50 != 100 || base.P95 != 500 {
+		t.Fatalf("Expected p50 of 100 and p95 of 500, got %f and %f", base.P50, base.P95)
+	}
+}
+
+func TestCombineHealingPerMana(t *testing.T) {
+	rsrc := &raidSimResultCombiner{}
+	shard := func(hpm, manaSpent float64) *proto.UnitMetrics {
+		return &proto.UnitMetrics{
+			Dps: rsrc.newDistMetrics(), Dpasp: rsrc.newDistMetrics(), Threat: rsrc.newDistMetrics(), Dtps: rsrc.newDistMetrics(),
+			Tmi: rsrc.newDistMetrics(), Hps: rsrc.newDistMetrics(), Tto: rsrc.newDistMetrics(),
+			HealingPerMana: hpm,
+			ManaSpentAvg:   manaSpent,
+		}
+	}
+
+	// 200 healing for 100 mana and 300 healing for 300 mana.
+	base := rsrc.newUnitMetrics(shard(0, 0))
+	rsrc.combineUnitMetrics(base, shard(2, 100), false, 0.5)
+	rsrc.combineUnitMetrics(base, shard(1, 300), true, 0.5)
+
+	if !WithinToleranceFloat64(base.ManaSpentAvg, 200, 1e-9) {
+		t.Fatalf("Expected 200 mana spent, got %f", base.ManaSpentAvg)
+	}
+	if !WithinToleranceFloat64(base.HealingPerMana, 1.25, 1e-9) {
+		t.Fatalf("Expected 1.25 healing per mana, got %f", base.HealingPerMana)
+	}
+}
@@ -75,6 +75,10 @@ type MovementHandler struct {
 	Moving    bool
 	MoveSpeed float64
 
+	// Set while an encounter movement event makes the unit move, see forced_movement.go.
+	forced         bool
+	forcedMoveAura *Aura
+
 	baseSpeed          float64
 	moveAura           *Aura
 	moveSpell          *Spell
@@ -113,6 +117,9 @@ func (unit *Unit) initMovement() {
 		},
 		OnExpire: func(aura *Aura, sim *Simulation) {
 			unit.MovementHandler.Moving = false
+			if unit.MovementHandler.forced {
+				return
+			}
 			unit.AutoAttacks.EnableAutoSwing(sim)
 
 			// Simulate the delay from starting attack
@@ -132,7 +139,7 @@ func (unit *Unit) initMovement() {
 }
 
 func (unit *Unit) IsMoving() bool {
-	return unit.MovementHandler.Moving
+	return unit.MovementHandler.Moving || unit.MovementHandler.forced
 }
 
 func (unit *Unit) MoveTo(moveRange float64, sim *Simulation) {
@@ -153,6 +160,7 @@ func (unit *Unit) MoveTo(moveRange float64, sim *Simulation) {
 
 		OnAction: func(sim *Simulation) {
 			unit.DistanceFromTarget += moveInterval
+			unit.placeAtDistanceFromTarget()
 			unit.MovementHandler.moveAura.SetStacks(sim, int32(unit.DistanceFromTarget))
 
 			if unit.DistanceFromTarget == moveRange {
@@ -0,0 +1,123 @@
+package core
+
+import (
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+const (
+	defaultObjectivePercentile  = 0.9
+	defaultObjectiveStdevFactor = 1.0
+)
+
+func validateObjective(objective *proto.OptimizationObjective) error {
+	switch objective.GetType() {
+	case proto.OptimizationObjectiveType_ObjectivePercentile:
+		if p := objective.Percentile; p < 0 || p >= 1 {
+			return fmt.Errorf("objective percentile must be between 0 and 1, got %g", p)
+		}
+	case proto.OptimizationObjectiveType_ObjectiveMeanMinusStdev:
+		if objective.StdevFactor < 0 {
+			return fmt.Errorf("objective stdev factor can't be negative, got %g", objective.StdevFactor)
+		}
+	case proto.OptimizationObjectiveType_ObjectiveChanceAboveThreshold:
+		if objective.DpsThreshold <= 0 {
+			return fmt.Errorf("objective dps threshold must be positive, got %g", objective.DpsThreshold)
+		}
+	}
+	return nil
+}
+
+// Whether the objective needs the per-iteration values, i.e. sims have to save all values.
+func objectiveNeedsAllValues(objective *proto.OptimizationObjective) bool {
+	switch objective.GetType() {
+	case proto.OptimizationObjectiveType_ObjectivePercentile, proto.OptimizationObjectiveType_ObjectiveChanceAboveThreshold:
+		return true
+	}
+	return false
+}
+
+// Scores a DPS distribution, higher is better.
+func objectiveScore(objective *proto.OptimizationObjective, dps *proto.DistributionMetrics) float64 {
+	switch objective.GetType() {
+	case proto.OptimizationObjectiveType_ObjectiveMeanMinusStdev:
+		return dps.Avg - objectiveStdevFactor(objective)*dps.Stdev
+	case proto.OptimizationObjectiveType_ObjectivePercentile, proto.OptimizationObjectiveType_ObjectiveChanceAboveThreshold:
+		return objectiveValuesScore(objective, dps.AllValues)
+	default:
+		return dps.Avg
+	}
+}
+
+// Scores per-iteration DPS values, e.g. a subset of the iterations of a sim.
+func objectiveValuesScore(objective *proto.OptimizationObjective, values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	switch objective.GetType() {
+	case proto.OptimizationObjectiveType_ObjectivePercentile:
+		sorted := slices.Clone(values)
+		slices.Sort(sorted)
+		return sorted[nearestRank(objectivePercentile(objective), len(sorted))]
+	case proto.OptimizationObjectiveType_ObjectiveChanceAboveThreshold:
+		above := 0
+		for _, value := range values {
+			if value > objective.DpsThreshold {
+				above++
+			}
+		}
+		return float64(above) / float64(len(values))
+	}
+
+	var agg aggregator
+	for _, value := range values {
+		agg.add(value)
+	}
+	mean, stdev := agg.meanAndStdDev()
+	if objective.GetType() == proto.OptimizationObjectiveType_ObjectiveMeanMinusStdev {
+		return mean - objectiveStdevFactor(objective)*stdev
+	}
+	return mean
+}
+
+func objectivePercentile(objective *proto.OptimizationObjective) float64 {
+	if objective.Percentile == 0 {
+		return defaultObjectivePercentile
+	}
+	return objective.Percentile
+}
+
+func objectiveStdevFactor(objective *proto.OptimizationObjective) float64 {
+	if objective.StdevFactor == 0 {
+		return defaultObjectiveStdevFactor
+	}
+	return objective.StdevFactor
+}
+
+// Number of batches paired iterations are split into to estimate the error of objectiveDelta.
+const objectiveDeltaBatches = 10
+
+// Returns the change in objective score from base to mod per unit of stat change, and its
+// standard error. Unlike the mean, percentiles and threshold chances aren't sums over
+// iterations, so the error is estimated from contiguous batches of paired iterations.
+func objectiveDelta(objective *proto.OptimizationObjective, base []float64, mod []float64, statMod float64) (float64, float64) {
+	n := min(len(base), len(mod))
+	delta := func(from, to int) float64 {
+		return (objectiveValuesScore(objective, mod[from:to]) - objectiveValuesScore(objective, base[from:to])) / statMod
+	}
+
+	if n < objectiveDeltaBatches {
+		return delta(0, n), 0
+	}
+
+	var batches aggregator
+	for i := 0; i < objectiveDeltaBatches; i++ {
+		batches.add(delta(i*n/objectiveDeltaBatches, (i+1)*n/objectiveDeltaBatches))
+	}
+	_, batchStdev := batches.meanAndStdDev()
+	return delta(0, n), batchStdev / math.Sqrt(objectiveDeltaBatches-1)
+}
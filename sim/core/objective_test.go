@@ -0,0 +1,50 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+func TestObjectiveValuesScore(t *testing.T) {
+	// A steady build and a swingy one with the same mean.
+	steady := []float64{1000, 1000, 1000, 1000}
+	swingy := []float64{600, 800, 1200, 1400}
+
+	for _, test := range []struct {
+		objective      *proto.OptimizationObjective
+		steady, swingy float64
+	}{
+		{&proto.OptimizationObjective{}, 1000, 1000},
+		{&proto.OptimizationObjective{Type: proto.OptimizationObjectiveType_ObjectivePercentile}, 1000, 1400},
+		{&proto.OptimizationObjective{Type: proto.OptimizationObjectiveType_ObjectivePercentile, Percentile: 0.5}, 1000, 800},
+		{&proto.OptimizationObjective{Type: proto.OptimizationObjectiveType_ObjectiveMeanMinusStdev, StdevFactor: 2}, 1000, 1000 - 2*316.22776601683796},
+		{&proto.OptimizationObjective{Type: proto.OptimizationObjectiveType_ObjectiveChanceAboveThreshold, DpsThreshold: 1100}, 0, 0.5},
+	} {
+		if score := objectiveValuesScore(test.objective, steady); !WithinToleranceFloat64(score, test.steady, 1e-9) {
+			t.Fatalf("Expected %f for the steady build with %v, got %f", test.steady, test.objective, score)
+		}
+		if score := objectiveValuesScore(test.objective, swingy); !WithinToleranceFloat64(score, test.swingy, 1e-9) {
+			t.Fatalf("Expected %f for the swingy build with %v, got %f", test.swingy, test.objective, score)
+		}
+	}
+}
+
+func TestObjectiveDelta(t *testing.T) {
+	base := make([]float64, 1000)
+	mod := make([]float64, 1000)
+	for i := range base {
+		base[i] = float64(1000 + i%100)
+		mod[i] = base[i] + 20
+	}
+
+	objective := &proto.OptimizationObjective{Type: proto.OptimizationObjectiveType_ObjectivePercentile}
+	mean, stdev := objectiveDelta(objective, base, mod, 10)
+	if !WithinToleranceFloat64(mean, 2, 1e-9) || !WithinToleranceFloat64(stdev, 0, 1e-9) {
+		t.Fatalf("Expected a weight of 2 without error, got %f +- %f", mean, stdev)
+	}
+
+	if err := validateObjective(&proto.OptimizationObjective{Type: proto.OptimizationObjectiveType_ObjectiveChanceAboveThreshold}); err == nil {
+		t.Fatalf("Expected an error for a missing dps threshold")
+	}
+}
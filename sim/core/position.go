@@ -0,0 +1,128 @@
+package core
+
+import (
+	"math"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+// A point or direction on the ground, in yards.
+type Vector2 struct {
+	X float64
+	Y float64
+}
+
+func Vector2FromProto(v *proto.Vector2) Vector2 {
+	return Vector2{X: v.GetX(), Y: v.GetY()}
+}
+
+// Unit direction for a facing in degrees, counter-clockwise from the x axis.
+func DirectionFromDegrees(degrees float64) Vector2 {
+	radians := degrees * math.Pi / 180
+	return Vector2{X: math.Cos(radians), Y: math.Sin(radians)}
+}
+
+func (v Vector2) Add(other Vector2) Vector2 {
+	return Vector2{X: v.X + other.X, Y: v.Y + other.Y}
+}
+func (v Vector2) Sub(other Vector2) Vector2 {
+	return Vector2{X: v.X - other.X, Y: v.Y - other.Y}
+}
+func (v Vector2) Scale(factor float64) Vector2 {
+	return Vector2{X: v.X * factor, Y: v.Y * factor}
+}
+func (v Vector2) Dot(other Vector2) float64 {
+	return v.X*other.X + v.Y*other.Y
+}
+func (v Vector2) Length() float64 {
+	return math.Hypot(v.X, v.Y)
+}
+func (v Vector2) DistanceTo(other Vector2) float64 {
+	return v.Sub(other).Length()
+}
+
+// Places the unit for a new iteration. Targets stand at their configured position, by default the
+// origin facing along the x axis. Players and pets without a configured position stand
+// DistanceFromTarget yards in front of or behind their target, matching InFrontOfTarget.
+func (unit *Unit) resetPosition() {
+	unit.Position = Vector2{}
+	if unit.startPosition != nil {
+		unit.Position = *unit.startPosition
+	} else if unit.Type != EnemyUnit && unit.CurrentTarget != nil {
+		unit.Position = unit.CurrentTarget.Position
+	}
+
+	if unit.Type == EnemyUnit || unit.CurrentTarget == nil {
+		return
+	}
+	if unit.startPosition == nil {
+		unit.placeAtDistanceFromTarget()
+	} else {
+		unit.DistanceFromTarget = unit.DistanceTo(unit.CurrentTarget)
+		unit.PseudoStats.InFrontOfTarget = unit.IsInFrontOf(unit.CurrentTarget)
+	}
+}
+
+// Moves the unit along the line to its target, so it stands DistanceFromTarget yards away.
+func (unit *Unit) placeAtDistanceFromTarget() {
+	target := unit.CurrentTarget
+	if target == nil {
+		return
+	}
+
+	direction := unit.Position.Sub(target.Position)
+	if direction.Length() == 0 {
+		direction = target.Facing
+		if !unit.PseudoStats.InFrontOfTarget {
+			direction = direction.Scale(-1)
+		}
+	}
+	unit.Position = target.Position.Add(direction.Scale(unit.DistanceFromTarget / direction.Length()))
+}
+
+func (unit *Unit) DistanceTo(other *Unit) float64 {
+	return unit.Position.DistanceTo(other.Position)
+}
+
+// Whether other is within the given range of the unit. Always true unless the encounter
+// places units explicitly, so ranges don't change results of position-less sims.
+func (unit *Unit) IsWithinRange(other *Unit, yards float64) bool {
+	return unit.IsWithinRadius(other.Position, yards)
+}
+
+// Whether the unit is within the given radius of a point, e.g. the center of a ground effect.
+// Always true unless the encounter places units explicitly.
+func (unit *Unit) IsWithinRadius(center Vector2, yards float64) bool {
+	return unit.Env == nil || !unit.Env.Positional || unit.Position.DistanceTo(center) <= yards
+}
+
+// Distance used for spell range checks against target.
+func (unit *Unit) RangeTo(target *Unit) float64 {
+	if unit.Env == nil || !unit.Env.Positional || target == nil {
+		return unit.DistanceFromTarget
+	}
+	return unit.DistanceTo(target)
+}
+
+// Players and pets face their target, targets keep their configured facing.
+func (unit *Unit) facingDirection() Vector2 {
+	if unit.Type != EnemyUnit && unit.CurrentTarget != nil {
+		if direction := unit.CurrentTarget.Position.Sub(unit.Position); direction.Length() > 0 {
+			return direction
+		}
+	}
+	return unit.Facing
+}
+
+// Whether the unit is in the frontal arc of defender, where its attacks can be parried and blocked.
+// Without explicit positions, this is the InFrontOfTarget setting.
+func (unit *Unit) IsInFrontOf(defender *Unit) bool {
+	if unit.Env == nil || !unit.Env.Positional || defender == nil {
+		return unit.PseudoStats.InFrontOfTarget
+	}
+	offset := unit.Position.Sub(defender.Position)
+	if offset.Length() == 0 {
+		return unit.PseudoStats.InFrontOfTarget
+	}
+	return defender.facingDirection().Dot(offset) >= 0
+}
@@ -0,0 +1,61 @@
+package core
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDirectionFromDegrees(t *testing.T) {
+	direction := DirectionFromDegrees(90)
+	if math.Abs(direction.X) > 1e-9 || math.Abs(direction.Y-1) > 1e-9 {
+		t.Fatalf("Expected (0, 1), got (%f, %f)", direction.X, direction.Y)
+	}
+	if length := DirectionFromDegrees(217).Length(); math.Abs(length-1) > 1e-9 {
+		t.Fatalf("Expected unit length, got %f", length)
+	}
+}
+
+func TestIsInFrontOf(t *testing.T) {
+	env := &Environment{Positional: true}
+	target := &Unit{Type: EnemyUnit, Env: env, Facing: DirectionFromDegrees(0)}
+	player := &Unit{Type: PlayerUnit, Env: env, CurrentTarget: target}
+
+	player.Position = Vector2{X: 3, Y: 4}
+	if !player.IsInFrontOf(target) {
+		t.Fatalf("Expected player at (3, 4) to be in front of target facing along x")
+	}
+	if distance := player.DistanceTo(target); distance != 5 {
+		t.Fatalf("Expected distance 5, got %f", distance)
+	}
+
+	player.Position = Vector2{X: -3, Y: 4}
+	if player.IsInFrontOf(target) {
+		t.Fatalf("Expected player at (-3, 4) to be behind target facing along x")
+	}
+	if player.IsWithinRadius(Vector2{}, 4) || !player.IsWithinRadius(Vector2{}, 5) {
+		t.Fatalf("Expected player to be 5 yards from the origin")
+	}
+
+	env.Positional = false
+	player.PseudoStats.InFrontOfTarget = true
+	if !player.IsInFrontOf(target) || !player.IsWithinRadius(Vector2{X: 100}, 1) {
+		t.Fatalf("Expected InFrontOfTarget setting and no radius limit without positions")
+	}
+}
+
+func TestWeaponAttackIsInRange(t *testing.T) {
+	env := &Environment{Positional: true}
+	target := &Unit{Type: EnemyUnit, Env: env}
+	player := &Unit{Type: PlayerUnit, Env: env, CurrentTarget: target, Position: Vector2{X: 20}}
+	melee := &WeaponAttack{Weapon: Weapon{MaxRange: MaxMeleeAttackRange}, unit: player}
+	ranged := &WeaponAttack{Weapon: Weapon{MinRange: 8, MaxRange: 35}, unit: player}
+
+	if melee.IsInRange() || !ranged.IsInRange() {
+		t.Fatalf("Expected only the ranged weapon in range of a target 20 yards away, though DistanceFromTarget is 0")
+	}
+
+	env.Positional = false
+	if !melee.IsInRange() || ranged.IsInRange() {
+		t.Fatalf("Expected DistanceFromTarget of 0 to be used without positions")
+	}
+}
@@ -140,7 +140,7 @@ func (raid *Raid) GetActiveUnits() []*Unit {
 }
 
 // Makes a new raid.
-func NewRaid(raidConfig *proto.Raid) *Raid {
+func NewRaid(raidConfig *proto.Raid, levelBracket LevelBracket) *Raid {
 	numParties := int(raidConfig.NumActiveParties)
 	if numParties == 0 {
 		numParties = len(raidConfig.Parties)
@@ -161,7 +161,7 @@ func NewRaid(raidConfig *proto.Raid) *Raid {
 	numDummies := min(24, int(raidConfig.TargetDummies))
 	for i := 0; i < numDummies; i++ {
 		party, partyIndex := raid.GetFirstEmptyRaidIndex()
-		dummy := NewTargetDummy(i, party, partyIndex)
+		dummy := NewTargetDummy(i, party, partyIndex, levelBracket.Level)
 		party.Players = append(party.Players, dummy)
 	}
 
@@ -267,12 +267,34 @@ func (raid *Raid) updatePlayersAndPets() {
 }
 
 func (raid *Raid) applyCharacterEffects(raidConfig *proto.Raid) *proto.RaidStats {
-	raidBuffs := raid.GetRaidBuffs(raidConfig.Buffs)
 	raidStats := &proto.RaidStats{}
 
+	// Duelists don't buff their opponent.
+	isDuel := len(raid.AllUnits) > 0 && raid.AllUnits[0].Env.duel != nil
+
+	var rosterSources []rosterBuffSource
+	var raidBuffs *proto.RaidBuffs
+	if raidConfig.DeriveBuffsFromRoster {
+		rosterSources = raid.rosterBuffSources()
+		raidStats.RosterEffects = rosterEffectsReport(rosterSources)
+	} else if !isDuel {
+		raidBuffs = raid.GetRaidBuffs(raidConfig.Buffs)
+	}
+
 	for partyIdx, party := range raid.Parties {
 		partyConfig := raidConfig.Parties[partyIdx]
 		partyBuffs := party.GetPartyBuffs(partyConfig.Buffs)
+
+		partyRaidBuffs := raidBuffs
+		if isDuel {
+			partyRaidBuffs = party.duelRaidBuffs()
+		} else if raidConfig.DeriveBuffsFromRoster {
+			partyRaidBuffs = rosterRaidBuffs(raidConfig.Buffs, rosterSources, party)
+			for _, player := range party.Players {
+				player.AddRaidBuffs(partyRaidBuffs)
+				player.GetCharacter().AddRaidBuffs(partyRaidBuffs)
+			}
+		}
 		partyStats := &proto.PartyStats{
 			Players: make([]*proto.PlayerStats, 5),
 		}
@@ -292,7 +314,10 @@ func (raid *Raid) applyCharacterEffects(raidConfig *proto.Raid) *proto.RaidStats
 			char := player.GetCharacter()
 			char.EnableHealthBar()
 			char.trackChanceOfDeath(playerConfig.HealingModel)
-			partyStats.Players[char.PartyIndex] = char.applyAllEffects(player, raidBuffs, partyBuffs, individualBuffs)
+			if char.Env.duel != nil {
+				char.Env.duel.registerDuelist(char)
+			}
+			partyStats.Players[char.PartyIndex] = char.applyAllEffects(player, partyRaidBuffs, partyBuffs, individualBuffs)
 
 			for _, pet := range char.Pets {
 				pet.EnableHealthBar()
@@ -0,0 +1,402 @@
+package core
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/wowsims/sod/sim/core/proto"
+	"github.com/wowsims/sod/sim/core/simsignals"
+	googleProto "google.golang.org/protobuf/proto"
+)
+
+const (
+	raidCompositionPartySize            = 5
+	raidCompositionMaxParties           = 8
+	defaultRaidCompositionCandidates    = 3
+	defaultRaidCompositionEstimateIters = 500
+	raidCompositionSearchRestarts       = 16
+	raidCompositionEmptySlot            = -1
+
+	// Swaps must improve the estimate by more than float rounding, so equivalent swaps aren't taken.
+	raidCompositionMinImprovement = 1e-9
+)
+
+// A party effect supplied by the roster, e.g. improved Battle Shout. Providers supplying
+// the same effect with identical buffs share an entry.
+type raidCompositionEffect struct {
+	nameIdx   int // Effects with the same name don't stack, e.g. regular and improved Battle Shout.
+	raidBuffs *proto.RaidBuffs
+}
+
+type raidComposer struct {
+	request *proto.RaidCompositionRequest
+	roster  []*proto.Player
+	seed    int64
+	signals simsignals.Signals
+
+	raidBuffs *proto.RaidBuffs
+	debuffs   *proto.Debuffs
+
+	effects         []raidCompositionEffect
+	numEffectNames  int
+	providedEffects [][]int // Effect indices supplied by each roster player.
+
+	soloDps []float64
+	gains   [][]float64 // Estimated DPS gained by each roster player from each effect.
+}
+
+// Each assignment is a list of parties holding roster indices, padded with empty slots.
+type raidAssignment [][]int
+
+// Estimates the value of every party effect for every player with short solo sims, searches
+// assignments maximizing the estimated raid DPS, and confirms the best ones with full raid sims.
+// Raid wide buffs and debuffs don't depend on the assignment, so they are held fixed.
+func runRaidComposition(request *proto.RaidCompositionRequest, signals simsignals.Signals) *proto.RaidCompositionResult {
+	numParties := (len(request.Roster) + raidCompositionPartySize - 1) / raidCompositionPartySize
+	if numParties == 0 {
+		return &proto.RaidCompositionResult{Error: &proto.ErrorOutcome{Message: "Roster is empty"}}
+	}
+	if numParties > raidCompositionMaxParties {
+		return &proto.RaidCompositionResult{Error: &proto.ErrorOutcome{
+			Message: fmt.Sprintf("Roster has %d players, at most %d are supported", len(request.Roster), raidCompositionMaxParties*raidCompositionPartySize),
+		}}
+	}
+	if slices.ContainsFunc(request.Roster, func(player *proto.Player) bool { return player == nil || player.Class == proto.Class_ClassUnknown }) {
+		return &proto.RaidCompositionResult{Error: &proto.ErrorOutcome{Message: "All roster players need a class"}}
+	}
+
+	composer := &raidComposer{
+		request: request,
+		roster:  request.Roster,
+		seed:    request.SimOptions.GetRandomSeed(),
+		signals: signals,
+	}
+	if composer.seed == 0 {
+		composer.seed = time.Now().UnixNano()
+	}
+
+	current := make(raidAssignment, numParties)
+	for i := range current {
+		current[i] = make([]int, raidCompositionPartySize)
+		for j := range current[i] {
+			if rosterIdx := i*raidCompositionPartySize + j; rosterIdx < len(request.Roster) {
+				current[i][j] = rosterIdx
+			} else {
+				current[i][j] = raidCompositionEmptySlot
+			}
+		}
+	}
+
+	if err := composer.collectEffects(current); err != nil {
+		return &proto.RaidCompositionResult{Error: &proto.ErrorOutcome{Message: err.Error()}}
+	}
+	if err := composer.estimateGains(); err != nil {
+		return &proto.RaidCompositionResult{Error: &proto.ErrorOutcome{Message: err.Error()}}
+	}
+
+	numCandidates := int(request.NumCandidates)
+	if numCandidates == 0 {
+		numCandidates = defaultRaidCompositionCandidates
+	}
+	candidates := composer.search(current, numCandidates)
+	if !slices.ContainsFunc(candidates, func(assignment raidAssignment) bool { return assignment.key() == current.key() }) {
+		candidates = append(candidates, current)
+	}
+
+	result := &proto.RaidCompositionResult{}
+	for _, assignment := range candidates {
+		candidate, err := composer.confirm(assignment)
+		if err != nil {
+			return &proto.RaidCompositionResult{Error: &proto.ErrorOutcome{Message: err.Error()}}
+		}
+		candidate.IsCurrent = assignment.key() == current.key()
+		result.Candidates = append(result.Candidates, candidate)
+	}
+	slices.SortStableFunc(result.Candidates, func(a, b *proto.RaidCompositionCandidate) int {
+		switch {
+		case a.Dps > b.Dps:
+			return -1
+		case a.Dps < b.Dps:
+			return 1
+		}
+		return 0
+	})
+	return result
+}
+
+func (composer *raidComposer) simFunc() func(*proto.RaidSimRequest, chan *proto.ProgressMetrics, simsignals.Signals) *proto.RaidSimResult {
+	// Don't use go threads in wasm, it just adds more overhead and makes the worker more unresponsive.
+	if IsRunningInWasm() || composer.request.SimOptions.GetIsTest() {
+		return RunSim
+	}
+	return runSimConcurrent
+}
+
+// All sims share a seed, so differences between them come from the buffs rather than RNG.
+func (composer *raidComposer) simOptions(iterations int32) *proto.SimOptions {
+	simOptions := &proto.SimOptions{}
+	if composer.request.SimOptions != nil {
+		simOptions = googleProto.Clone(composer.request.SimOptions).(*proto.SimOptions)
+	}
+	if iterations != 0 {
+		simOptions.Iterations = iterations
+		simOptions.TargetRelativeError = 0
+	}
+	simOptions.UseLabeledRands = true
+	simOptions.RandomSeed = composer.seed
+	return simOptions
+}
+
+func (composer *raidComposer) collectEffects(assignment raidAssignment) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("failed to construct the roster: %v", r)
+		}
+	}()
+
+	raidProto := composer.raidProto(assignment)
+	levelBracket, err := RaidLevelBracket(raidProto)
+	if err != nil {
+		return err
+	}
+	raid := NewRaid(raidProto, levelBracket)
+	sources := raid.rosterBuffSources()
+
+	composer.raidBuffs = rosterRaidBuffs(composer.request.RaidBuffs, sources, nil)
+	composer.debuffs = rosterDebuffs(composer.request.Debuffs, sources)
+
+	composer.providedEffects = make([][]int, len(composer.roster))
+	effectIndices := make(map[string]int)
+	nameIndices := make(map[string]int)
+	for _, source := range sources {
+		if source.Scope != proto.RosterEffectScope_RosterEffectScopeParty || source.RaidBuffs == nil {
+			continue
+		}
+
+		data, _ := googleProto.MarshalOptions{Deterministic: true}.Marshal(source.RaidBuffs)
+		key := source.Name + "|" + string(data)
+		idx, ok := effectIndices[key]
+		if !ok {
+			nameIdx, ok := nameIndices[source.Name]
+			if !ok {
+				nameIdx = len(nameIndices)
+				nameIndices[source.Name] = nameIdx
+			}
+			idx = len(composer.effects)
+			effectIndices[key] = idx
+			composer.effects = append(composer.effects, raidCompositionEffect{nameIdx: nameIdx, raidBuffs: source.RaidBuffs})
+		}
+
+		provider := assignment[source.party.Index][source.provider.GetCharacter().PartyIndex]
+		composer.providedEffects[provider] = append(composer.providedEffects[provider], idx)
+	}
+	composer.numEffectNames = len(nameIndices)
+	return nil
+}
+
+func (composer *raidComposer) estimateGains() error {
+	iterations := composer.request.EstimateIterations
+	if iterations == 0 {
+		iterations = defaultRaidCompositionEstimateIters
+	}
+	simFunc := composer.simFunc()
+
+	soloDps := func(player *proto.Player, raidBuffs *proto.RaidBuffs) (float64, error) {
+		result := simFunc(&proto.RaidSimRequest{
+			Raid:       SinglePlayerRaidProto(player, nil, raidBuffs, composer.debuffs),
+			Encounter:  composer.request.Encounter,
+			SimOptions: composer.simOptions(iterations),
+		}, nil, composer.signals)
+		if result.Error != nil {
+			return 0, fmt.Errorf("%s: %s", player.Name, result.Error.Message)
+		}
+		return result.RaidMetrics.Parties[0].Players[0].Dps.Avg, nil
+	}
+
+	composer.soloDps = make([]float64, len(composer.roster))
+	composer.gains = make([][]float64, len(composer.roster))
+	for i, player := range composer.roster {
+		base, err := soloDps(player, composer.raidBuffs)
+		if err != nil {
+			return err
+		}
+		composer.soloDps[i] = base
+
+		composer.gains[i] = make([]float64, len(composer.effects))
+		for j, effect := range composer.effects {
+			raidBuffs := googleProto.Clone(composer.raidBuffs).(*proto.RaidBuffs)
+			mergeRosterEffect(raidBuffs.ProtoReflect(), effect.raidBuffs.ProtoReflect())
+			buffed, err := soloDps(player, raidBuffs)
+			if err != nil {
+				return err
+			}
+			composer.gains[i][j] = buffed - base
+		}
+	}
+	return nil
+}
+
+// Estimated raid DPS of an assignment. When a party has several versions of the same effect,
+// each member only counts the most valuable one.
+func (composer *raidComposer) estimate(assignment raidAssignment) float64 {
+	total := 0.0
+	best := make([]float64, composer.numEffectNames)
+	found := make([]bool, composer.numEffectNames)
+	for _, party := range assignment {
+		for _, receiver := range party {
+			if receiver == raidCompositionEmptySlot {
+				continue
+			}
+			total += composer.soloDps[receiver]
+
+			clear(found)
+			for _, provider := range party {
+				if provider == raidCompositionEmptySlot {
+					continue
+				}
+				for _, effectIdx := range composer.providedEffects[provider] {
+					nameIdx := composer.effects[effectIdx].nameIdx
+					if gain := composer.gains[receiver][effectIdx]; !found[nameIdx] || gain > best[nameIdx] {
+						best[nameIdx] = gain
+						found[nameIdx] = true
+					}
+				}
+			}
+			for nameIdx, gain := range best {
+				if found[nameIdx] {
+					total += gain
+				}
+			}
+		}
+	}
+	return total
+}
+
+// Hill climbing on slot swaps between parties, restarted from shuffled assignments.
+// Returns the best distinct local optima.
+func (composer *raidComposer) search(start raidAssignment, numCandidates int) []raidAssignment {
+	rng := rand.New(rand.NewSource(composer.seed))
+	optima := make(map[string]raidAssignment)
+
+	for restart := 0; restart < raidCompositionSearchRestarts; restart++ {
+		assignment := start.clone()
+		if restart > 0 {
+			slots := assignment.flatten()
+			rng.Shuffle(len(slots), func(i, j int) { slots[i], slots[j] = slots[j], slots[i] })
+			assignment = unflattenRaidAssignment(slots, len(start))
+		}
+
+		score := composer.estimate(assignment)
+		for improved := true; improved; {
+			improved = false
+			for a := 0; a < len(assignment)*raidCompositionPartySize; a++ {
+				for b := a + 1; b < len(assignment)*raidCompositionPartySize; b++ {
+					partyA, slotA := a/raidCompositionPartySize, a%raidCompositionPartySize
+					partyB, slotB := b/raidCompositionPartySize, b%raidCompositionPartySize
+					if partyA == partyB || assignment[partyA][slotA] == assignment[partyB][slotB] {
+						continue
+					}
+
+					assignment[partyA][slotA], assignment[partyB][slotB] = assignment[partyB][slotB], assignment[partyA][slotA]
+					if newScore := composer.estimate(assignment); newScore > score*(1+raidCompositionMinImprovement) {
+						score = newScore
+						improved = true
+					} else {
+						assignment[partyA][slotA], assignment[partyB][slotB] = assignment[partyB][slotB], assignment[partyA][slotA]
+					}
+				}
+			}
+		}
+		optima[assignment.key()] = assignment
+	}
+
+	candidates := make([]raidAssignment, 0, len(optima))
+	for _, assignment := range optima {
+		candidates = append(candidates, assignment)
+	}
+	scores := make(map[string]float64, len(candidates))
+	for _, assignment := range candidates {
+		scores[assignment.key()] = composer.estimate(assignment)
+	}
+	slices.SortFunc(candidates, func(a, b raidAssignment) int {
+		if scoreA, scoreB := scores[a.key()], scores[b.key()]; scoreA != scoreB {
+			if scoreA > scoreB {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a.key(), b.key())
+	})
+	return candidates[:min(numCandidates, len(candidates))]
+}
+
+func (composer *raidComposer) confirm(assignment raidAssignment) (*proto.RaidCompositionCandidate, error) {
+	raidProto := composer.raidProto(assignment)
+	result := composer.simFunc()(&proto.RaidSimRequest{
+		Raid:       raidProto,
+		Encounter:  composer.request.Encounter,
+		SimOptions: composer.simOptions(0),
+	}, nil, composer.signals)
+	if result.Error != nil {
+		return nil, fmt.Errorf("%s", result.Error.Message)
+	}
+
+	return &proto.RaidCompositionCandidate{
+		Raid:         raidProto,
+		EstimatedDps: composer.estimate(assignment),
+		Dps:          result.RaidMetrics.Dps.Avg,
+		DpsStdev:     result.RaidMetrics.Dps.Stdev,
+	}, nil
+}
+
+func (composer *raidComposer) raidProto(assignment raidAssignment) *proto.Raid {
+	raidProto := &proto.Raid{
+		Buffs:                 composer.request.RaidBuffs,
+		Debuffs:               composer.request.Debuffs,
+		DeriveBuffsFromRoster: true,
+	}
+	for _, party := range assignment {
+		partyProto := &proto.Party{}
+		for _, rosterIdx := range party {
+			if rosterIdx != raidCompositionEmptySlot {
+				partyProto.Players = append(partyProto.Players, composer.roster[rosterIdx])
+			}
+		}
+		raidProto.Parties = append(raidProto.Parties, partyProto)
+	}
+	return raidProto
+}
+
+func (assignment raidAssignment) clone() raidAssignment {
+	return MapSlice(assignment, slices.Clone[[]int])
+}
+
+func (assignment raidAssignment) flatten() []int {
+	var slots []int
+	for _, party := range assignment {
+		slots = append(slots, party...)
+	}
+	return slots
+}
+
+func unflattenRaidAssignment(slots []int, numParties int) raidAssignment {
+	assignment := make(raidAssignment, numParties)
+	for i := range assignment {
+		assignment[i] = slots[i*raidCompositionPartySize : (i+1)*raidCompositionPartySize]
+	}
+	return assignment
+}
+
+// Identifies assignments regardless of the order of parties and of players within a party.
+func (assignment raidAssignment) key() string {
+	parties := MapSlice(assignment, func(party []int) string {
+		players := slices.Clone(party)
+		slices.Sort(players)
+		return fmt.Sprint(players)
+	})
+	slices.Sort(parties)
+	return strings.Join(parties, "")
+}
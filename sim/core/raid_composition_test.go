@@ -0,0 +1,52 @@
+package core
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestRaidCompositionSearch(t *testing.T) {
+	// Player 0 supplies a party effect which only players 6 to 9 gain from.
+	composer := &raidComposer{
+		seed:            1,
+		effects:         []raidCompositionEffect{{nameIdx: 0}},
+		numEffectNames:  1,
+		providedEffects: make([][]int, 10),
+		soloDps:         make([]float64, 10),
+		gains:           make([][]float64, 10),
+	}
+	composer.providedEffects[0] = []int{0}
+	for i := range composer.gains {
+		composer.gains[i] = []float64{0}
+		if i >= 6 {
+			composer.gains[i][0] = 10
+		}
+	}
+
+	current := raidAssignment{{0, 1, 2, 3, 4}, {5, 6, 7, 8, 9}}
+	if estimate := composer.estimate(current); estimate != 0 {
+		t.Fatalf("Expected estimate of 0 for the current assignment, got %f", estimate)
+	}
+
+	best := composer.search(current, 1)[0]
+	if estimate := composer.estimate(best); estimate != 40 {
+		t.Fatalf("Expected estimate of 40 for the best assignment, got %f", estimate)
+	}
+	for _, party := range best {
+		if slices.Contains(party, 0) {
+			for _, player := range []int{6, 7, 8, 9} {
+				if !slices.Contains(party, player) {
+					t.Fatalf("Expected player %d in the party of player 0, got %v", player, best)
+				}
+			}
+		}
+	}
+}
+
+func TestRaidAssignmentKey(t *testing.T) {
+	a := raidAssignment{{0, 1, -1}, {2, 3, 4}}
+	b := raidAssignment{{4, 3, 2}, {-1, 1, 0}}
+	if a.key() != b.key() {
+		t.Fatalf("Expected equal keys for reordered assignments, got %s and %s", a.key(), b.key())
+	}
+}
@@ -0,0 +1,74 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wowsims/sod/sim/core/proto"
+	"github.com/wowsims/sod/sim/core/simsignals"
+	googleProto "google.golang.org/protobuf/proto"
+)
+
+func runRaidNight(request *proto.RaidNightRequest, signals simsignals.Signals) *proto.RaidNightResult {
+	if request.Raid == nil {
+		return &proto.RaidNightResult{Error: &proto.ErrorOutcome{Message: "No raid"}}
+	}
+	if len(request.Encounters) == 0 {
+		return &proto.RaidNightResult{Error: &proto.ErrorOutcome{Message: "No encounters"}}
+	}
+
+	simFunc := runSimConcurrent
+	// Don't use go threads in wasm, it just adds more overhead and makes the worker more unresponsive.
+	if IsRunningInWasm() || request.SimOptions.GetIsTest() {
+		simFunc = RunSim
+	}
+
+	result := &proto.RaidNightResult{}
+	elapsed := time.Duration(0)
+	for i, encounter := range request.Encounters {
+		if encounter.GapSeconds < 0 {
+			return &proto.RaidNightResult{Error: &proto.ErrorOutcome{Message: fmt.Sprintf("Encounter %d: gap can't be negative", i+1)}}
+		}
+		elapsed += DurationFromSeconds(encounter.GapSeconds)
+
+		raid, err := raidAtPull(request.Raid, elapsed)
+		if err != nil {
+			return &proto.RaidNightResult{Error: &proto.ErrorOutcome{Message: fmt.Sprintf("Encounter %d: %s", i+1, err)}}
+		}
+
+		simResult := simFunc(&proto.RaidSimRequest{
+			Raid:       raid,
+			Encounter:  encounter.Encounter,
+			SimOptions: request.SimOptions,
+		}, nil, signals)
+		if simResult.Error != nil {
+			return &proto.RaidNightResult{Error: &proto.ErrorOutcome{Message: fmt.Sprintf("Encounter %d: %s", i+1, simResult.Error.Message)}}
+		}
+
+		result.Encounters = append(result.Encounters, &proto.RaidNightEncounterResult{
+			PullSeconds: elapsed.Seconds(),
+			Raid:        raid,
+			Result:      simResult,
+		})
+		elapsed += DurationFromSeconds(simResult.AvgIterationDuration)
+	}
+	return result
+}
+
+// Copy of the raid with the world buff timers of all players run down to a pull.
+func raidAtPull(raid *proto.Raid, elapsed time.Duration) (*proto.Raid, error) {
+	raid = googleProto.Clone(raid).(*proto.Raid)
+	for _, party := range raid.GetParties() {
+		for _, player := range party.Players {
+			if player == nil {
+				continue
+			}
+			buffs, err := worldBuffsAtPull(player.Buffs, elapsed)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", player.Name, err)
+			}
+			player.Buffs = buffs
+		}
+	}
+	return raid, nil
+}
@@ -0,0 +1,330 @@
+package core
+
+import (
+	"math"
+	"slices"
+	"time"
+
+	"github.com/wowsims/sod/sim/core/proto"
+	"github.com/wowsims/sod/sim/core/simsignals"
+	googleProto "google.golang.org/protobuf/proto"
+)
+
+// Changes smaller than this are rounding noise between the two results, not a difference.
+const resultDiffEpsilon = 1e-9
+
+// Compares two results of the same player, simming the requests first when the results are
+// missing, and explains the change in DPS by the actions, auras and resources that changed.
+func runResultDiff(request *proto.ResultDiffRequest, signals simsignals.Signals) *proto.ResultDiffResult {
+	if (request.Before == nil && request.BeforeRequest == nil) || (request.After == nil && request.AfterRequest == nil) {
+		return &proto.ResultDiffResult{Error: &proto.ErrorOutcome{Message: "A diff needs a result or request for both sides"}}
+	}
+
+	// Simming both sides with the same seed cancels out most of the noise between them.
+	seed := time.Now().UnixNano()
+	simIfMissing := func(result *proto.RaidSimResult, simRequest *proto.RaidSimRequest) *proto.RaidSimResult {
+		if result != nil {
+			return result
+		}
+		simRequest = googleProto.Clone(simRequest).(*proto.RaidSimRequest)
+		if simRequest.SimOptions == nil {
+			simRequest.SimOptions = &proto.SimOptions{}
+		}
+		if simRequest.SimOptions.RandomSeed == 0 {
+			simRequest.SimOptions.RandomSeed = seed
+		}
+
+		simFunc := runSimConcurrent
+		// Don't use go threads in wasm, it just adds more overhead and makes the worker more unresponsive.
+		if IsRunningInWasm() || simRequest.SimOptions.IsTest {
+			simFunc = RunSim
+		}
+		return simFunc(simRequest, nil, signals)
+	}
+	before := simIfMissing(request.Before, request.BeforeRequest)
+	after := simIfMissing(request.After, request.AfterRequest)
+
+	for _, result := range []*proto.RaidSimResult{before, after} {
+		if result.Error != nil {
+			return &proto.ResultDiffResult{Error: result.Error}
+		}
+		if result.AvgIterationDuration <= 0 || result.IterationsDone <= 0 {
+			return &proto.ResultDiffResult{Error: &proto.ErrorOutcome{Message: "Results need their iteration count and duration"}}
+		}
+	}
+
+	beforePlayer := resultDiffPlayer(before, request.PartyIndex, request.PlayerIndex)
+	afterPlayer := resultDiffPlayer(after, request.PartyIndex, request.PlayerIndex)
+	if beforePlayer == nil || afterPlayer == nil {
+		return &proto.ResultDiffResult{Error: &proto.ErrorOutcome{Message: "Both results need the player being compared"}}
+	}
+
+	return diffUnitResults(
+		resultDiffSide{metrics: beforePlayer, iterations: float64(before.IterationsDone), duration: before.AvgIterationDuration},
+		resultDiffSide{metrics: afterPlayer, iterations: float64(after.IterationsDone), duration: after.AvgIterationDuration},
+	)
+}
+
+func resultDiffPlayer(result *proto.RaidSimResult, partyIndex int32, playerIndex int32) *proto.UnitMetrics {
+	parties := result.GetRaidMetrics().GetParties()
+	if partyIndex < 0 || int(partyIndex) >= len(parties) {
+		return nil
+	}
+	players := parties[partyIndex].Players
+	if playerIndex < 0 || int(playerIndex) >= len(players) {
+		return nil
+	}
+	return players[playerIndex]
+}
+
+// One side of the diff, with what's needed to turn totals over all iterations into rates.
+type resultDiffSide struct {
+	metrics    *proto.UnitMetrics
+	iterations float64
+	duration   float64 // Average iteration length, in seconds.
+}
+
+// Totals over all iterations of an action, summed over its targets.
+type resultDiffAction struct {
+	casts    float64
+	attempts float64
+	landed   float64
+	events   float64 // Landed hits and ticks.
+	crits    float64
+	damage   float64
+}
+
+// Identifies an action, aura or resource of the player or one of its pets.
+type resultDiffKey struct {
+	petName      string
+	actionID     ActionID
+	resourceType proto.ResourceType
+}
+
+func (side resultDiffSide) units() []*proto.UnitMetrics {
+	return append([]*proto.UnitMetrics{side.metrics}, side.metrics.Pets...)
+}
+
+func (side resultDiffSide) petName(unitIndex int) string {
+	if unitIndex == 0 {
+		return ""
+	}
+	return side.metrics.Pets[unitIndex-1].Name
+}
+
+func (side resultDiffSide) actions() map[resultDiffKey]*resultDiffAction {
+	actions := make(map[resultDiffKey]*resultDiffAction)
+	for i, unit := range side.units() {
+		for _, action := range unit.Actions {
+			key := resultDiffKey{petName: side.petName(i), actionID: ProtoToActionID(action.Id)}
+			totals := actions[key]
+			if totals == nil {
+				totals = &resultDiffAction{}
+				actions[key] = totals
+			}
+			for _, target := range action.Targets {
+				landed := float64(target.Hits + target.Crits + target.Glances + target.Blocks + target.BlockedCrits + target.Crushes)
+				totals.casts += float64(target.Casts)
+				totals.landed += landed
+				totals.attempts += landed + float64(target.Misses+target.Dodges+target.Parries)
+				totals.events += landed + float64(target.Ticks+target.CritTicks)
+				totals.crits += float64(target.Crits + target.BlockedCrits + target.CritTicks)
+				totals.damage += target.Damage
+			}
+		}
+	}
+	return actions
+}
+
+func (side resultDiffSide) perIteration(total float64) float64 {
+	return total / side.iterations
+}
+
+func (side resultDiffSide) perSecond(total float64) float64 {
+	return total / side.iterations / side.duration
+}
+
+func safeRatio(numerator float64, denominator float64) float64 {
+	if denominator == 0 {
+		return 0
+	}
+	return numerator / denominator
+}
+
+func diffUnitResults(before resultDiffSide, after resultDiffSide) *proto.ResultDiffResult {
+	result := &proto.ResultDiffResult{
+		DpsBefore: before.metrics.GetDps().GetAvg(),
+		DpsAfter:  after.metrics.GetDps().GetAvg(),
+	}
+
+	beforeActions, afterActions := before.actions(), after.actions()
+	explained := 0.0
+	for _, key := range resultDiffKeys(beforeActions, afterActions) {
+		b, a := beforeActions[key], afterActions[key]
+		if b == nil {
+			b = &resultDiffAction{}
+		}
+		if a == nil {
+			a = &resultDiffAction{}
+		}
+
+		diff := &proto.ActionDiff{
+			Id:              key.actionID.ToProto(),
+			PetName:         key.petName,
+			DpsBefore:       before.perSecond(b.damage),
+			DpsAfter:        after.perSecond(a.damage),
+			CastsBefore:     before.perIteration(b.casts),
+			CastsAfter:      after.perIteration(a.casts),
+			HitRateBefore:   safeRatio(b.landed, b.attempts),
+			HitRateAfter:    safeRatio(a.landed, a.attempts),
+			CritRateBefore:  safeRatio(b.crits, b.events),
+			CritRateAfter:   safeRatio(a.crits, a.events),
+			AvgDamageBefore: safeRatio(b.damage, b.events),
+			AvgDamageAfter:  safeRatio(a.damage, a.events),
+		}
+		delta := diff.DpsAfter - diff.DpsBefore
+		diff.DpsDeltaFromCasts, diff.DpsDeltaFromDamagePerCast = splitActionDpsDelta(
+			before.perSecond(b.casts), after.perSecond(a.casts), diff.DpsBefore, diff.DpsAfter)
+
+		if math.Abs(delta) < resultDiffEpsilon && math.Abs(diff.CastsAfter-diff.CastsBefore) < resultDiffEpsilon {
+			continue
+		}
+		explained += delta
+		result.Actions = append(result.Actions, diff)
+	}
+	slices.SortStableFunc(result.Actions, func(x, y *proto.ActionDiff) int {
+		return compareBySize(x.DpsAfter-x.DpsBefore, y.DpsAfter-y.DpsBefore)
+	})
+	result.UnexplainedDpsDelta = result.DpsAfter - result.DpsBefore - explained
+
+	result.Auras = diffAuras(before, after)
+	result.Resources = diffResources(before, after)
+	return result
+}
+
+// Splits the change in an action's DPS between its cast rate and its damage per cast, each
+// valued at the average of the other's two sides so that the parts add up to the change.
+func splitActionDpsDelta(castRateBefore float64, castRateAfter float64, dpsBefore float64, dpsAfter float64) (fromCasts float64, fromDamagePerCast float64) {
+	delta := dpsAfter - dpsBefore
+	switch {
+	case castRateBefore > 0 && castRateAfter > 0:
+		damagePerCastBefore := dpsBefore / castRateBefore
+		damagePerCastAfter := dpsAfter / castRateAfter
+		fromCasts = (castRateAfter - castRateBefore) * (damagePerCastBefore + damagePerCastAfter) / 2
+		return fromCasts, delta - fromCasts
+	case castRateBefore > 0 || castRateAfter > 0:
+		// Only cast on one side.
+		return delta, 0
+	default:
+		// Procs and other damage without casts.
+		return 0, delta
+	}
+}
+
+func diffAuras(before resultDiffSide, after resultDiffSide) []*proto.AuraDiff {
+	collect := func(side resultDiffSide) map[resultDiffKey]*proto.AuraMetrics {
+		auras := make(map[resultDiffKey]*proto.AuraMetrics)
+		for i, unit := range side.units() {
+			for _, aura := range unit.Auras {
+				auras[resultDiffKey{petName: side.petName(i), actionID: ProtoToActionID(aura.Id)}] = aura
+			}
+		}
+		return auras
+	}
+	beforeAuras, afterAuras := collect(before), collect(after)
+
+	var diffs []*proto.AuraDiff
+	for _, key := range resultDiffKeys(beforeAuras, afterAuras) {
+		b, a := beforeAuras[key], afterAuras[key]
+		diff := &proto.AuraDiff{
+			Id:           key.actionID.ToProto(),
+			PetName:      key.petName,
+			UptimeBefore: b.GetUptimeSecondsAvg() / before.duration,
+			UptimeAfter:  a.GetUptimeSecondsAvg() / after.duration,
+			ProcsBefore:  b.GetProcsAvg(),
+			ProcsAfter:   a.GetProcsAvg(),
+		}
+		if math.Abs(diff.UptimeAfter-diff.UptimeBefore) < resultDiffEpsilon && math.Abs(diff.ProcsAfter-diff.ProcsBefore) < resultDiffEpsilon {
+			continue
+		}
+		diffs = append(diffs, diff)
+	}
+	slices.SortStableFunc(diffs, func(x, y *proto.AuraDiff) int {
+		return compareBySize(x.UptimeAfter-x.UptimeBefore, y.UptimeAfter-y.UptimeBefore)
+	})
+	return diffs
+}
+
+func diffResources(before resultDiffSide, after resultDiffSide) []*proto.ResourceDiff {
+	collect := func(side resultDiffSide) map[resultDiffKey]float64 {
+		gains := make(map[resultDiffKey]float64)
+		for i, unit := range side.units() {
+			for _, resource := range unit.Resources {
+				key := resultDiffKey{petName: side.petName(i), actionID: ProtoToActionID(resource.Id), resourceType: resource.Type}
+				gains[key] += side.perSecond(resource.ActualGain)
+			}
+		}
+		return gains
+	}
+	beforeGains, afterGains := collect(before), collect(after)
+
+	var diffs []*proto.ResourceDiff
+	for _, key := range resultDiffKeys(beforeGains, afterGains) {
+		diff := &proto.ResourceDiff{
+			Id:                  key.actionID.ToProto(),
+			PetName:             key.petName,
+			Type:                key.resourceType,
+			GainPerSecondBefore: beforeGains[key],
+			GainPerSecondAfter:  afterGains[key],
+		}
+		if math.Abs(diff.GainPerSecondAfter-diff.GainPerSecondBefore) < resultDiffEpsilon {
+			continue
+		}
+		diffs = append(diffs, diff)
+	}
+	slices.SortStableFunc(diffs, func(x, y *proto.ResourceDiff) int {
+		return compareBySize(x.GainPerSecondAfter-x.GainPerSecondBefore, y.GainPerSecondAfter-y.GainPerSecondBefore)
+	})
+	return diffs
+}
+
+// Keys present on either side, in a stable order so that ties keep the same order between runs.
+func resultDiffKeys[V any](before map[resultDiffKey]V, after map[resultDiffKey]V) []resultDiffKey {
+	keys := make([]resultDiffKey, 0, len(before)+len(after))
+	for key := range before {
+		keys = append(keys, key)
+	}
+	for key := range after {
+		if _, ok := before[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.SortFunc(keys, func(x, y resultDiffKey) int {
+		if x.petName != y.petName {
+			if x.petName < y.petName {
+				return -1
+			}
+			return 1
+		}
+		if x.actionID != y.actionID {
+			if x.actionID.String() < y.actionID.String() {
+				return -1
+			}
+			return 1
+		}
+		return int(x.resourceType) - int(y.resourceType)
+	})
+	return keys
+}
+
+// Orders larger changes first, regardless of their direction.
+func compareBySize(x float64, y float64) int {
+	switch {
+	case math.Abs(x) > math.Abs(y):
+		return -1
+	case math.Abs(x) < math.Abs(y):
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,73 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+func TestSplitActionDpsDelta(t *testing.T) {
+	// Twice the casts at the same damage per cast.
+	fromCasts, fromDamagePerCast := splitActionDpsDelta(0.1, 0.2, 100, 200)
+	if !WithinToleranceFloat64(fromCasts, 100, 1e-9) || !WithinToleranceFloat64(fromDamagePerCast, 0, 1e-9) {
+		t.Fatalf("Expected all of the delta from casts, got %f and %f", fromCasts, fromDamagePerCast)
+	}
+
+	fromCasts, fromDamagePerCast = splitActionDpsDelta(0.1, 0.15, 100, 180)
+	if !WithinToleranceFloat64(fromCasts+fromDamagePerCast, 80, 1e-9) {
+		t.Fatalf("Expected the parts to add up to 80, got %f and %f", fromCasts, fromDamagePerCast)
+	}
+
+	if fromCasts, _ := splitActionDpsDelta(0, 0.1, 0, 50); fromCasts != 50 {
+		t.Fatalf("Expected a new action to be explained by casts, got %f", fromCasts)
+	}
+}
+
+func TestDiffUnitResults(t *testing.T) {
+	boltID := ActionID{SpellID: 100}
+	procID := ActionID{SpellID: 200}
+
+	unit := func(dps float64, boltCasts int32, boltCrits int32, procDamage float64, uptime float64) *proto.UnitMetrics {
+		return &proto.UnitMetrics{
+			Dps: &proto.DistributionMetrics{Avg: dps},
+			Actions: []*proto.ActionMetrics{
+				{Id: boltID.ToProto(), Targets: []*proto.TargetedActionMetrics{{
+					Casts:  boltCasts,
+					Hits:   boltCasts - boltCrits,
+					Crits:  boltCrits,
+					Damage: float64(boltCasts) * 1000,
+				}}},
+				{Id: procID.ToProto(), Targets: []*proto.TargetedActionMetrics{{
+					Hits:   10,
+					Damage: procDamage,
+				}}},
+			},
+			Auras: []*proto.AuraMetrics{
+				{Id: procID.ToProto(), UptimeSecondsAvg: uptime, ProcsAvg: 1},
+			},
+		}
+	}
+
+	// 10 iterations of 100 seconds.
+	before := resultDiffSide{metrics: unit(110, 100, 20, 1000, 50), iterations: 10, duration: 100}
+	after := resultDiffSide{metrics: unit(130, 120, 30, 1000, 20), iterations: 10, duration: 100}
+
+	result := diffUnitResults(before, after)
+	if len(result.Actions) != 1 {
+		t.Fatalf("Expected only the changed action, got %v", result.Actions)
+	}
+	bolt := result.Actions[0]
+	if ProtoToActionID(bolt.Id) != boltID || !WithinToleranceFloat64(bolt.DpsAfter-bolt.DpsBefore, 20, 1e-9) {
+		t.Fatalf("Expected 20 DPS from the bolt, got %v", bolt)
+	}
+	if !WithinToleranceFloat64(bolt.CritRateBefore, 0.2, 1e-9) || !WithinToleranceFloat64(bolt.CritRateAfter, 0.25, 1e-9) {
+		t.Fatalf("Unexpected crit rates %f and %f", bolt.CritRateBefore, bolt.CritRateAfter)
+	}
+	if !WithinToleranceFloat64(result.UnexplainedDpsDelta, 0, 1e-9) {
+		t.Fatalf("Expected the actions to explain the whole delta, got %f left", result.UnexplainedDpsDelta)
+	}
+
+	if len(result.Auras) != 1 || !WithinToleranceFloat64(result.Auras[0].UptimeAfter-result.Auras[0].UptimeBefore, -0.3, 1e-9) {
+		t.Fatalf("Expected the aura uptime to drop by 30%%, got %v", result.Auras)
+	}
+}
@@ -0,0 +1,114 @@
+package core
+
+import (
+	"github.com/wowsims/sod/sim/core/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Implemented by agents that supply buffs or debuffs to other raid members, based on their
+// class, talents and options. Only used when the raid derives its buffs from the roster.
+type RosterBuffProvider interface {
+	RosterBuffs() []RosterBuff
+}
+
+// A single effect supplied by a raid member. Fields set in RaidBuffs or Debuffs are merged
+// into the buffs of the receivers, keeping the strongest value when several members supply
+// the same effect.
+type RosterBuff struct {
+	Name  string
+	Scope proto.RosterEffectScope
+
+	RaidBuffs *proto.RaidBuffs
+	Debuffs   *proto.Debuffs
+}
+
+type rosterBuffSource struct {
+	RosterBuff
+	provider Agent
+	party    *Party
+}
+
+func (raid *Raid) rosterBuffSources() []rosterBuffSource {
+	var sources []rosterBuffSource
+	for _, party := range raid.Parties {
+		for _, player := range party.Players {
+			provider, ok := player.(RosterBuffProvider)
+			if !ok {
+				continue
+			}
+			for _, buff := range provider.RosterBuffs() {
+				sources = append(sources, rosterBuffSource{RosterBuff: buff, provider: player, party: party})
+			}
+		}
+	}
+	return sources
+}
+
+// Raid buffs received by members of the given party. Only toggles which can't be supplied
+// by a raid member are kept from the configured buffs.
+func rosterRaidBuffs(configBuffs *proto.RaidBuffs, sources []rosterBuffSource, party *Party) *proto.RaidBuffs {
+	raidBuffs := &proto.RaidBuffs{}
+	if configBuffs != nil {
+		raidBuffs.ScrollOfProtection = configBuffs.ScrollOfProtection
+		raidBuffs.ScrollOfStamina = configBuffs.ScrollOfStamina
+		raidBuffs.ScrollOfStrength = configBuffs.ScrollOfStrength
+		raidBuffs.ScrollOfAgility = configBuffs.ScrollOfAgility
+		raidBuffs.ScrollOfIntellect = configBuffs.ScrollOfIntellect
+		raidBuffs.ScrollOfSpirit = configBuffs.ScrollOfSpirit
+	}
+
+	for _, source := range sources {
+		if source.RaidBuffs == nil || (source.Scope == proto.RosterEffectScope_RosterEffectScopeParty && source.party != party) {
+			continue
+		}
+		mergeRosterEffect(raidBuffs.ProtoReflect(), source.RaidBuffs.ProtoReflect())
+	}
+	return raidBuffs
+}
+
+// Debuffs applied to all targets. Item based debuffs and debuff timings are kept from the configured debuffs.
+func rosterDebuffs(configDebuffs *proto.Debuffs, sources []rosterBuffSource) *proto.Debuffs {
+	debuffs := &proto.Debuffs{}
+	if configDebuffs != nil {
+		debuffs.GiftOfArthas = configDebuffs.GiftOfArthas
+		debuffs.Thunderfury = configDebuffs.Thunderfury
+		debuffs.MekkatorqueFistDebuff = configDebuffs.MekkatorqueFistDebuff
+		debuffs.SerpentsStrikerFistDebuff = configDebuffs.SerpentsStrikerFistDebuff
+		debuffs.Timings = configDebuffs.Timings
+	}
+
+	for _, source := range sources {
+		if source.Debuffs != nil {
+			mergeRosterEffect(debuffs.ProtoReflect(), source.Debuffs.ProtoReflect())
+		}
+	}
+	return debuffs
+}
+
+// Merges all populated fields of src into dst, keeping the larger value. This matches the
+// ordering of TristateEffect, so improved versions win over regular ones.
+func mergeRosterEffect(dst protoreflect.Message, src protoreflect.Message) {
+	src.Range(func(fd protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		current := dst.Get(fd)
+		switch fd.Kind() {
+		case protoreflect.BoolKind:
+			dst.Set(fd, protoreflect.ValueOfBool(current.Bool() || value.Bool()))
+		case protoreflect.EnumKind:
+			dst.Set(fd, protoreflect.ValueOfEnum(max(current.Enum(), value.Enum())))
+		case protoreflect.Int32Kind:
+			dst.Set(fd, protoreflect.ValueOfInt32(int32(max(current.Int(), value.Int()))))
+		}
+		return true
+	})
+}
+
+func rosterEffectsReport(sources []rosterBuffSource) []*proto.RosterEffect {
+	return MapSlice(sources, func(source rosterBuffSource) *proto.RosterEffect {
+		return &proto.RosterEffect{
+			Effect:     source.Name,
+			Provider:   source.provider.GetCharacter().Name,
+			PartyIndex: int32(source.party.Index),
+			Scope:      source.Scope,
+		}
+	})
+}
@@ -0,0 +1,67 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+func TestRosterRaidBuffs(t *testing.T) {
+	party0 := &Party{Index: 0}
+	party1 := &Party{Index: 1}
+	sources := []rosterBuffSource{
+		{
+			RosterBuff: RosterBuff{
+				Scope:     proto.RosterEffectScope_RosterEffectScopeParty,
+				RaidBuffs: &proto.RaidBuffs{BattleShout: proto.TristateEffect_TristateEffectImproved},
+			},
+			party: party0,
+		},
+		{
+			RosterBuff: RosterBuff{
+				Scope:     proto.RosterEffectScope_RosterEffectScopeParty,
+				RaidBuffs: &proto.RaidBuffs{BattleShout: proto.TristateEffect_TristateEffectRegular},
+			},
+			party: party1,
+		},
+		{
+			RosterBuff: RosterBuff{
+				Scope:     proto.RosterEffectScope_RosterEffectScopeRaid,
+				RaidBuffs: &proto.RaidBuffs{PowerWordFortitude: proto.TristateEffect_TristateEffectRegular, ArcaneBrilliance: true},
+			},
+			party: party1,
+		},
+	}
+	config := &proto.RaidBuffs{
+		ScrollOfStrength: true,
+		GiftOfTheWild:    proto.TristateEffect_TristateEffectImproved,
+	}
+
+	buffs := rosterRaidBuffs(config, sources, party0)
+	if buffs.BattleShout != proto.TristateEffect_TristateEffectImproved {
+		t.Fatalf("Expected improved Battle Shout, got %s", buffs.BattleShout)
+	}
+	if buffs.PowerWordFortitude != proto.TristateEffect_TristateEffectRegular || !buffs.ArcaneBrilliance {
+		t.Fatalf("Expected raid wide buffs from another party, got %v", buffs)
+	}
+	if !buffs.ScrollOfStrength {
+		t.Fatalf("Expected scrolls to be kept from the config")
+	}
+	if buffs.GiftOfTheWild != proto.TristateEffect_TristateEffectMissing {
+		t.Fatalf("Expected Gift of the Wild toggle to be ignored without a druid, got %s", buffs.GiftOfTheWild)
+	}
+
+	buffs = rosterRaidBuffs(config, sources, party1)
+	if buffs.BattleShout != proto.TristateEffect_TristateEffectRegular {
+		t.Fatalf("Expected regular Battle Shout, got %s", buffs.BattleShout)
+	}
+}
+
+func TestMergeRosterEffect(t *testing.T) {
+	debuffs := &proto.Debuffs{SunderArmor: true, Homunculi: 70}
+	mergeRosterEffect(debuffs.ProtoReflect(), (&proto.Debuffs{Homunculi: 50, FaerieFire: true}).ProtoReflect())
+
+	if !debuffs.SunderArmor || !debuffs.FaerieFire || debuffs.Homunculi != 70 {
+		t.Fatalf("Unexpected merge result: %v", debuffs)
+	}
+}
@@ -0,0 +1,380 @@
+// Package sharelink converts between the sim UI's share links, their settings protos and
+// runnable RaidSimRequests.
+//
+// A link is the URL of a sim UI with the zlib compressed, base64 encoded settings as fragment.
+// Links to the raid sim hold RaidSimSettings, links to any other sim IndividualSimSettings.
+//
+// Converting a request to a link and back gives the same request, with two exceptions: a
+// request without iterations comes back with the UI's default of 3000, and debug and test
+// options are dropped. Converting settings to a request and back loses the blessing
+// assignments, as the request only holds the buffs they applied.
+package sharelink
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+
+	"github.com/wowsims/sod/sim/core/proto"
+	goproto "google.golang.org/protobuf/proto"
+)
+
+// Root of the sims site, each sim UI lives in a directory below it.
+const DefaultBaseURL = "https://wowsims.github.io/sod/"
+
+const raidSimDirectory = "raid"
+
+// Used by the UI when the settings don't hold an iteration count.
+const defaultIterations = 3000
+
+var ErrInvalidLink = errors.New("invalid wowsims export link")
+
+// Returned when a request can only be expressed by a raid sim link.
+var ErrNotIndividual = errors.New("request needs a raid sim link")
+
+func IsRaidLink(link string) bool {
+	return strings.Contains(link, "/"+raidSimDirectory+"/")
+}
+
+// Decodes the settings of a link, either *proto.IndividualSimSettings or *proto.RaidSimSettings.
+func Decode(link string) (goproto.Message, error) {
+	_, fragment, found := strings.Cut(link, "#")
+	if !found || fragment == "" {
+		return nil, ErrInvalidLink
+	}
+	// Chat clients sometimes escape the fragment.
+	if strings.Contains(fragment, "%") {
+		unescaped, err := url.PathUnescape(fragment)
+		if err != nil {
+			return nil, fmt.Errorf("cannot unescape link: %w", err)
+		}
+		fragment = unescaped
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(fragment)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode proto from link: %w", err)
+	}
+
+	r, err := zlib.NewReader(bytes.NewReader(raw))
+	if err != nil {
+		return nil, fmt.Errorf("cannot create zlib reader: %w", err)
+	}
+	defer r.Close()
+
+	var buf bytes.Buffer
+	if _, err := buf.ReadFrom(r); err != nil {
+		return nil, fmt.Errorf("reading zlib data failed: %w", err)
+	}
+
+	var settings goproto.Message
+	if IsRaidLink(link) {
+		settings = &proto.RaidSimSettings{}
+	} else {
+		settings = &proto.IndividualSimSettings{}
+	}
+	if err := goproto.Unmarshal(buf.Bytes(), settings); err != nil {
+		return nil, fmt.Errorf("cannot unmarshal raw proto: %w", err)
+	}
+	return settings, nil
+}
+
+// Encodes settings into the fragment of a link.
+func EncodeSettings(settings goproto.Message) (string, error) {
+	data, err := goproto.Marshal(settings)
+	if err != nil {
+		return "", fmt.Errorf("cannot marshal settings: %w", err)
+	}
+
+	var buffer bytes.Buffer
+	writer := zlib.NewWriter(&buffer)
+	if _, err := writer.Write(data); err != nil {
+		return "", fmt.Errorf("cannot compress settings: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return "", fmt.Errorf("cannot compress settings: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(buffer.Bytes()), nil
+}
+
+// Decodes a link straight into the request the sim UI would run for it.
+func DecodeRequest(link string) (*proto.RaidSimRequest, error) {
+	settings, err := Decode(link)
+	if err != nil {
+		return nil, err
+	}
+	switch settings := settings.(type) {
+	case *proto.RaidSimSettings:
+		return RaidSettingsToRequest(settings), nil
+	default:
+		return IndividualSettingsToRequest(settings.(*proto.IndividualSimSettings)), nil
+	}
+}
+
+// Encodes a request as a link to the individual sim of its player, or to the raid sim when it
+// has more than one player or uses settings only the raid sim has.
+func EncodeRequest(baseURL string, request *proto.RaidSimRequest) (string, error) {
+	settings, err := RequestToIndividualSettings(request)
+	if errors.Is(err, ErrNotIndividual) {
+		return EncodeRaidRequest(baseURL, request)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	fragment, err := EncodeSettings(settings)
+	if err != nil {
+		return "", err
+	}
+	return simURL(baseURL, specDirectory(settings.Player)) + "#" + fragment, nil
+}
+
+// Encodes a request as a link to the raid sim, whatever its number of players.
+func EncodeRaidRequest(baseURL string, request *proto.RaidSimRequest) (string, error) {
+	fragment, err := EncodeSettings(RequestToRaidSettings(request))
+	if err != nil {
+		return "", err
+	}
+	return simURL(baseURL, raidSimDirectory) + "#" + fragment, nil
+}
+
+func simURL(baseURL string, directory string) string {
+	baseURL, _, _ = strings.Cut(baseURL, "#")
+	if !strings.HasSuffix(baseURL, "/") {
+		baseURL += "/"
+	}
+	return baseURL + directory + "/"
+}
+
+// The UI directory of a player's spec matches the name of its field in the spec oneof, e.g.
+// balance_druid.
+func specDirectory(player *proto.Player) string {
+	message := player.ProtoReflect()
+	field := message.WhichOneof(message.Descriptor().Oneofs().ByName("spec"))
+	if field == nil {
+		return ""
+	}
+	return string(field.Name())
+}
+
+func IndividualSettingsToRequest(settings *proto.IndividualSimSettings) *proto.RaidSimRequest {
+	settings = goproto.Clone(settings).(*proto.IndividualSimSettings)
+
+	return &proto.RaidSimRequest{
+		Raid: &proto.Raid{
+			Parties: []*proto.Party{
+				{
+					Players: []*proto.Player{settings.Player},
+					Buffs:   settings.PartyBuffs,
+				},
+			},
+			Buffs:         settings.RaidBuffs,
+			Debuffs:       settings.Debuffs,
+			Tanks:         settings.Tanks,
+			TargetDummies: settings.TargetDummies,
+		},
+		Encounter:  settings.Encounter,
+		SimOptions: settingsToSimOptions(settings.Settings),
+	}
+}
+
+// Converts raid settings, applying the paladins' blessing assignments to the players' buffs
+// as the raid sim does before simming.
+func RaidSettingsToRequest(settings *proto.RaidSimSettings) *proto.RaidSimRequest {
+	settings = goproto.Clone(settings).(*proto.RaidSimSettings)
+	raid := settings.Raid
+	if raid == nil {
+		raid = &proto.Raid{}
+	}
+	applyBlessings(raid, settings.Blessings)
+
+	return &proto.RaidSimRequest{
+		Raid:       raid,
+		Encounter:  settings.Encounter,
+		SimOptions: settingsToSimOptions(settings.Settings),
+	}
+}
+
+// Converts a request with a single player to individual sim settings. Returns ErrNotIndividual
+// if the request holds anything individual settings can't, like a second player or raid wide
+// options such as external buff casts.
+func RequestToIndividualSettings(request *proto.RaidSimRequest) (*proto.IndividualSimSettings, error) {
+	if request.Raid == nil {
+		return nil, fmt.Errorf("request has no raid")
+	}
+	rest := goproto.Clone(request.Raid).(*proto.Raid)
+
+	settings := &proto.IndividualSimSettings{
+		Settings:      simOptionsToSettings(request.SimOptions),
+		RaidBuffs:     rest.Buffs,
+		Debuffs:       rest.Debuffs,
+		Tanks:         rest.Tanks,
+		TargetDummies: rest.TargetDummies,
+	}
+	if request.Encounter != nil {
+		settings.Encounter = goproto.Clone(request.Encounter).(*proto.Encounter)
+	}
+
+	for i, party := range rest.Parties {
+		for j, player := range party.Players {
+			if player == nil || player.Class == proto.Class_ClassUnknown {
+				continue
+			}
+			if i != 0 || j != 0 || settings.Player != nil {
+				return nil, ErrNotIndividual
+			}
+			settings.Player = player
+			settings.PartyBuffs = party.Buffs
+		}
+	}
+	if settings.Player == nil {
+		return nil, fmt.Errorf("request has no player")
+	}
+
+	// Whatever remains once the fields the individual settings hold are cleared only exists
+	// in raid settings. Parties without players don't matter.
+	rest.Parties = nil
+	rest.Buffs, rest.Debuffs, rest.Tanks, rest.TargetDummies = nil, nil, nil, 0
+	rest.NumActiveParties = 0
+	if !goproto.Equal(rest, &proto.Raid{}) {
+		return nil, ErrNotIndividual
+	}
+
+	return settings, nil
+}
+
+// Converts a request to raid settings. Blessings the request was built with are already part of
+// its players' buffs, so the settings hold no blessing assignments.
+func RequestToRaidSettings(request *proto.RaidSimRequest) *proto.RaidSimSettings {
+	request = goproto.Clone(request).(*proto.RaidSimRequest)
+	return &proto.RaidSimSettings{
+		Settings:  simOptionsToSettings(request.SimOptions),
+		Raid:      request.Raid,
+		Encounter: request.Encounter,
+	}
+}
+
+// Debug and test options aren't settings, so they aren't part of links.
+func simOptionsToSettings(options *proto.SimOptions) *proto.SimSettings {
+	if options == nil {
+		return nil
+	}
+	return &proto.SimSettings{
+		Iterations:          options.Iterations,
+		FixedRngSeed:        options.RandomSeed,
+		TargetRelativeError: options.TargetRelativeError,
+		MinIterations:       options.MinIterations,
+	}
+}
+
+// Settings without iterations run the UI's default, so a request with none doesn't survive a
+// round trip through a link.
+func settingsToSimOptions(settings *proto.SimSettings) *proto.SimOptions {
+	options := &proto.SimOptions{
+		Iterations:          settings.GetIterations(),
+		RandomSeed:          settings.GetFixedRngSeed(),
+		TargetRelativeError: settings.GetTargetRelativeError(),
+		MinIterations:       settings.GetMinIterations(),
+	}
+	if options.Iterations == 0 {
+		options.Iterations = defaultIterations
+	}
+	return options
+}
+
+// Each paladin in the raid, in order, casts the blessing assigned to a spec on all players of
+// that spec. Assignments beyond the number of paladins are ignored.
+func applyBlessings(raid *proto.Raid, blessings *proto.BlessingsAssignments) {
+	if blessings == nil {
+		return
+	}
+
+	players := activePlayers(raid)
+	numPaladins := 0
+	for _, player := range players {
+		if player.Class == proto.Class_ClassPaladin {
+			numPaladins++
+		}
+	}
+
+	for _, party := range raid.Parties {
+		for _, player := range party.Players {
+			if player == nil || player.Class == proto.Class_ClassUnknown {
+				continue
+			}
+			spec, ok := playerSpec(player)
+			if !ok {
+				continue
+			}
+			for i, paladin := range blessings.Paladins {
+				if i >= numPaladins || int(spec) >= len(paladin.Blessings) {
+					continue
+				}
+				if player.Buffs == nil {
+					player.Buffs = &proto.IndividualBuffs{}
+				}
+				switch paladin.Blessings[spec] {
+				case proto.Blessings_BlessingOfKings:
+					player.Buffs.BlessingOfKings = true
+				case proto.Blessings_BlessingOfMight:
+					player.Buffs.BlessingOfMight = proto.TristateEffect_TristateEffectImproved
+				case proto.Blessings_BlessingOfWisdom:
+					player.Buffs.BlessingOfWisdom = proto.TristateEffect_TristateEffectImproved
+				case proto.Blessings_BlessingOfSanctuary:
+					player.Buffs.BlessingOfSanctuary = true
+				}
+			}
+		}
+	}
+}
+
+func activePlayers(raid *proto.Raid) []*proto.Player {
+	parties := raid.Parties
+	if raid.NumActiveParties > 0 && int(raid.NumActiveParties) < len(parties) {
+		parties = parties[:raid.NumActiveParties]
+	}
+
+	var players []*proto.Player
+	for _, party := range parties {
+		for _, player := range party.Players {
+			if player != nil && player.Class != proto.Class_ClassUnknown {
+				players = append(players, player)
+			}
+		}
+	}
+	return players
+}
+
+// Spec enum values are named after the spec oneof types, e.g. Player_Mage and SpecMage. Unlike
+// core.PlayerProtoToSpec this doesn't need the agents to be registered.
+func playerSpec(player *proto.Player) (proto.Spec, bool) {
+	if player.Spec == nil {
+		return 0, false
+	}
+	name := strings.TrimPrefix(reflect.TypeOf(player.Spec).Elem().Name(), "Player_")
+	spec, ok := proto.Spec_value["Spec"+name]
+	return proto.Spec(spec), ok
+}
+
+// Players whose rotation is generated by the UI from its auto or simple settings. Links only
+// store those settings, so the sim runs the priority list the link happens to hold instead.
+func UnresolvedRotations(request *proto.RaidSimRequest) []string {
+	var names []string
+	for _, party := range request.GetRaid().GetParties() {
+		for _, player := range party.Players {
+			if player == nil || player.Class == proto.Class_ClassUnknown {
+				continue
+			}
+			switch player.GetRotation().GetType() {
+			case proto.APLRotation_TypeAuto, proto.APLRotation_TypeSimple:
+				names = append(names, player.Name)
+			}
+		}
+	}
+	return names
+}
@@ -0,0 +1,175 @@
+package sharelink
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/wowsims/sod/sim/core/proto"
+	goproto "google.golang.org/protobuf/proto"
+)
+
+func testMage() *proto.Player {
+	return &proto.Player{
+		Name:     "Mage",
+		Class:    proto.Class_ClassMage,
+		Level:    60,
+		Rotation: &proto.APLRotation{Type: proto.APLRotation_TypeAPL},
+		Spec:     &proto.Player_Mage{Mage: &proto.Mage{}},
+	}
+}
+
+func testPaladin() *proto.Player {
+	return &proto.Player{
+		Name:     "Paladin",
+		Class:    proto.Class_ClassPaladin,
+		Level:    60,
+		Rotation: &proto.APLRotation{Type: proto.APLRotation_TypeAPL},
+		Spec:     &proto.Player_RetributionPaladin{RetributionPaladin: &proto.RetributionPaladin{}},
+	}
+}
+
+func testRequest(players ...*proto.Player) *proto.RaidSimRequest {
+	return &proto.RaidSimRequest{
+		Raid: &proto.Raid{
+			Parties: []*proto.Party{{
+				Players: players,
+				Buffs:   &proto.PartyBuffs{AtieshMage: 1},
+			}},
+			Buffs:         &proto.RaidBuffs{ArcaneBrilliance: true},
+			Debuffs:       &proto.Debuffs{JudgementOfWisdom: true},
+			TargetDummies: 2,
+		},
+		Encounter: &proto.Encounter{Duration: 120, Targets: []*proto.Target{{Level: 63}}},
+		SimOptions: &proto.SimOptions{
+			Iterations:          5000,
+			RandomSeed:          123,
+			TargetRelativeError: 0.001,
+		},
+	}
+}
+
+func TestIndividualRoundTrip(t *testing.T) {
+	request := testRequest(testMage())
+
+	link, err := EncodeRequest(DefaultBaseURL, request)
+	if err != nil {
+		t.Fatalf("Failed to encode: %v", err)
+	}
+	if !strings.HasPrefix(link, DefaultBaseURL+"mage/#") {
+		t.Fatalf("Expected a link to the mage sim, got %s", link)
+	}
+
+	decoded, err := DecodeRequest(link)
+	if err != nil {
+		t.Fatalf("Failed to decode: %v", err)
+	}
+	if !goproto.Equal(decoded, request) {
+		t.Fatalf("Round trip changed the request:\n%v\n%v", request, decoded)
+	}
+}
+
+func TestRaidRoundTrip(t *testing.T) {
+	request := testRequest(testMage(), testPaladin())
+	request.Raid.DeriveBuffsFromRoster = true
+
+	if _, err := RequestToIndividualSettings(request); !errors.Is(err, ErrNotIndividual) {
+		t.Fatalf("Expected ErrNotIndividual for two players, got %v", err)
+	}
+
+	link, err := EncodeRequest(DefaultBaseURL, request)
+	if err != nil {
+		t.Fatalf("Failed to encode: %v", err)
+	}
+	if !IsRaidLink(link) {
+		t.Fatalf("Expected a raid sim link, got %s", link)
+	}
+
+	decoded, err := DecodeRequest(link)
+	if err != nil {
+		t.Fatalf("Failed to decode: %v", err)
+	}
+	if !goproto.Equal(decoded, request) {
+		t.Fatalf("Round trip changed the request:\n%v\n%v", request, decoded)
+	}
+}
+
+func TestRaidSettingsBlessings(t *testing.T) {
+	request := testRequest(testMage(), testPaladin())
+	settings := RequestToRaidSettings(request)
+
+	settings.Blessings = &proto.BlessingsAssignments{
+		Paladins: []*proto.BlessingsAssignment{
+			{Blessings: []proto.Blessings{proto.Spec_SpecMage: proto.Blessings_BlessingOfWisdom}},
+			// Ignored, there's only one paladin.
+			{Blessings: []proto.Blessings{proto.Spec_SpecMage: proto.Blessings_BlessingOfKings}},
+		},
+	}
+
+	raid := RaidSettingsToRequest(settings).Raid
+	mage := raid.Parties[0].Players[0]
+	if mage.GetBuffs().GetBlessingOfWisdom() != proto.TristateEffect_TristateEffectImproved || mage.GetBuffs().GetBlessingOfKings() {
+		t.Fatalf("Expected only Blessing of Wisdom on the mage, got %v", mage.Buffs)
+	}
+	if paladin := raid.Parties[0].Players[1]; paladin.GetBuffs().GetBlessingOfWisdom() != proto.TristateEffect_TristateEffectMissing {
+		t.Fatalf("Expected no blessing on the paladin, got %v", paladin.Buffs)
+	}
+}
+
+func TestRoundTripDefaultIterations(t *testing.T) {
+	request := testRequest(testMage())
+	request.SimOptions.Iterations = 0
+
+	link, err := EncodeRequest(DefaultBaseURL, request)
+	if err != nil {
+		t.Fatalf("Failed to encode: %v", err)
+	}
+	decoded, err := DecodeRequest(link)
+	if err != nil {
+		t.Fatalf("Failed to decode: %v", err)
+	}
+	if decoded.SimOptions.Iterations != defaultIterations {
+		t.Fatalf("Expected a request without iterations to decode with %d, got %d", defaultIterations, decoded.SimOptions.Iterations)
+	}
+
+	decoded.SimOptions.Iterations = 0
+	if !goproto.Equal(decoded, request) {
+		t.Fatalf("Round trip changed more than the iterations:\n%v\n%v", request, decoded)
+	}
+}
+
+func TestRoundTripBlessings(t *testing.T) {
+	settings := RequestToRaidSettings(testRequest(testMage(), testPaladin()))
+	settings.Blessings = &proto.BlessingsAssignments{
+		Paladins: []*proto.BlessingsAssignment{
+			{Blessings: []proto.Blessings{proto.Spec_SpecMage: proto.Blessings_BlessingOfKings}},
+		},
+	}
+
+	request := RaidSettingsToRequest(settings)
+	roundTrip := RequestToRaidSettings(request)
+	if roundTrip.Blessings != nil {
+		t.Fatalf("Expected the blessing assignments to be dropped, got %v", roundTrip.Blessings)
+	}
+	if !roundTrip.Raid.Parties[0].Players[0].GetBuffs().GetBlessingOfKings() {
+		t.Fatalf("Expected the applied Blessing of Kings to remain on the mage, got %v", roundTrip.Raid.Parties[0].Players[0].Buffs)
+	}
+
+	link, err := EncodeRaidRequest(DefaultBaseURL, request)
+	if err != nil {
+		t.Fatalf("Failed to encode: %v", err)
+	}
+	decoded, err := DecodeRequest(link)
+	if err != nil {
+		t.Fatalf("Failed to decode: %v", err)
+	}
+	if !goproto.Equal(decoded, request) {
+		t.Fatalf("Round trip changed the request:\n%v\n%v", request, decoded)
+	}
+}
+
+func TestDecodeInvalidLink(t *testing.T) {
+	if _, err := Decode("https://wowsims.github.io/sod/mage/"); !errors.Is(err, ErrInvalidLink) {
+		t.Fatalf("Expected ErrInvalidLink, got %v", err)
+	}
+}
@@ -251,6 +251,11 @@ func (sim *Simulation) RandomExpFloat(label string) float64 {
 	return rand.New(sim.labelRand(label)).ExpFloat64()
 }
 
+// Returns a normally distributed float64 with mean 0 and standard deviation 1.
+func (sim *Simulation) RandomNormFloat(label string) float64 {
+	return rand.New(sim.labelRand(label)).NormFloat64()
+}
+
 // Shorthand for commonly-used RNG behavior.
 // Returns a random number between min and max.
 func (sim *Simulation) Roll(min float64, max float64) float64 {
@@ -308,6 +313,10 @@ func (sim *Simulation) run() *proto.RaidSimResult {
 		sim.Log = nil
 	}
 
+	adaptive := isAdaptive(sim.Options)
+	minIterations := adaptiveMinIterations(sim.Options)
+	iterationsDone := int32(1)
+
 	var st time.Time
 	for i := int32(1); i < sim.Options.Iterations; i++ {
 		if sim.Signals.Abort.IsTriggered() {
@@ -339,6 +348,12 @@ func (sim *Simulation) run() *proto.RaidSimResult {
 			iterDuration = sim.CurrentTime
 		}
 		totalDuration += iterDuration
+		iterationsDone++
+
+		if adaptive && iterationsDone >= minIterations && (iterationsDone-minIterations)%adaptiveBatchIterations == 0 &&
+			sim.Raid.relativeError() <= sim.Options.TargetRelativeError {
+			break
+		}
 	}
 	result := &proto.RaidSimResult{
 		RaidMetrics:      sim.Raid.GetMetrics(),
@@ -346,16 +361,17 @@ func (sim *Simulation) run() *proto.RaidSimResult {
 
 		Logs:                   logsBuffer.String(),
 		FirstIterationDuration: firstIterationDuration.Seconds(),
-		AvgIterationDuration:   totalDuration.Seconds() / float64(sim.Options.Iterations),
-		IterationsDone:         sim.Options.Iterations,
+		AvgIterationDuration:   totalDuration.Seconds() / float64(iterationsDone),
+		IterationsDone:         iterationsDone,
+		RelativeError:          sim.Raid.relativeError(),
 	}
 
 	// Final progress report
 	if sim.ProgressReport != nil {
-		sim.ProgressReport(&proto.ProgressMetrics{TotalIterations: sim.Options.Iterations, CompletedIterations: sim.Options.Iterations, Dps: result.RaidMetrics.Dps.Avg, FinalRaidResult: result})
+		sim.ProgressReport(&proto.ProgressMetrics{TotalIterations: iterationsDone, CompletedIterations: iterationsDone, Dps: result.RaidMetrics.Dps.Avg, FinalRaidResult: result})
 	}
 
-	if d := sim.Options.Iterations; d > 3000 {
+	if d := iterationsDone; d > 3000 {
 		log.Printf("running %d iterations took %s", d, time.Since(t0))
 	}
 
@@ -473,6 +489,12 @@ func (sim *Simulation) Cleanup() {
 	}
 }
 
+// Ends the current iteration now, e.g. when a player died in a duel.
+func (sim *Simulation) endCombat() {
+	sim.Duration = max(sim.CurrentTime, time.Millisecond)
+	sim.endOfCombatDuration = sim.CurrentTime
+}
+
 func (sim *Simulation) runPendingActions() {
 	for {
 		if finished := sim.Step(); finished {
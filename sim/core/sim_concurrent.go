@@ -34,6 +34,11 @@ func SplitSimRequestForConcurrency(request *proto.RaidSimRequest, splitCount int
 
 	split[0] = googleProto.Clone(request).(*proto.RaidSimRequest)
 	split[0].SimOptions.Iterations = iterPerSplit + request.SimOptions.Iterations%splitCount
+	if isAdaptive(request.SimOptions) {
+		// Each split stops on its own, so loosen its target such that the combined result meets the requested one.
+		split[0].SimOptions.TargetRelativeError *= math.Sqrt(float64(splitCount))
+		split[0].SimOptions.MinIterations = adaptiveMinIterations(request.SimOptions) / splitCount
+	}
 
 	// Sims increment their seed each iteration. Offset starting seed of each split to emulate that.
 	nextStartSeed := split[0].SimOptions.RandomSeed + int64(split[0].SimOptions.Iterations)
@@ -41,6 +46,8 @@ func SplitSimRequestForConcurrency(request *proto.RaidSimRequest, splitCount int
 	for i := 1; i < int(splitCount); i++ {
 		split[i] = googleProto.Clone(request).(*proto.RaidSimRequest)
 		split[i].SimOptions.Iterations = iterPerSplit
+		split[i].SimOptions.TargetRelativeError = split[0].SimOptions.TargetRelativeError
+		split[i].SimOptions.MinIterations = split[0].SimOptions.MinIterations
 		split[i].SimOptions.DebugFirstIteration = false // No logs
 		split[i].SimOptions.RandomSeed = nextStartSeed
 		nextStartSeed += int64(split[i].SimOptions.Iterations)
@@ -132,14 +139,43 @@ func (rsrc *raidSimResultCombiner) combineDistMetrics(base *proto.DistributionMe
 
 	base.AllValues = append(base.AllValues, add.AllValues...)
 
+	base.HistBucketSize = add.HistBucketSize
+
 	base.AggregatorData.N += add.AggregatorData.N
 	base.AggregatorData.SumSq += add.AggregatorData.SumSq
 	if isLast {
-		base.Stdev = math.Sqrt(base.AggregatorData.SumSq/float64(base.AggregatorData.N) - base.Avg*base.Avg)
+		rsrc.finishDistMetrics(base)
+	}
+}
+
+func (rsrc *raidSimResultCombiner) finishDistMetrics(base *proto.DistributionMetrics) {
+	if base.AggregatorData.N == 0 {
+		base.Min, base.MinSeed = 0, 0
+		return
 	}
+	base.Stdev = math.Sqrt(base.AggregatorData.SumSq/float64(base.AggregatorData.N) - base.Avg*base.Avg)
+
+	p := distributionPercentiles(base.AllValues, base.Hist)
+	base.P5, base.P50, base.P95 = p[0], p[1], p[2]
+}
+
+// Like combineDistMetrics, for distributions whose values aren't one per iteration
+// (e.g. damage per hit), so they're weighted by their own counts instead.
+func (rsrc *raidSimResultCombiner) combineCountedDistMetrics(base *proto.DistributionMetrics, add *proto.DistributionMetrics) {
+	// Shards without values have a zero min, which would otherwise reset the combined min.
+	if add.AggregatorData.N == 0 {
+		return
+	}
+	if base.AggregatorData.N == 0 {
+		base.Min, base.MinSeed = add.Min, add.MinSeed
+	}
+
+	total := base.AggregatorData.N + add.AggregatorData.N
+	base.Avg *= float64(base.AggregatorData.N) / float64(total)
+	rsrc.combineDistMetrics(base, add, false, float64(add.AggregatorData.N)/float64(total))
 }
 
-func (rsrc *raidSimResultCombiner) addActionMetrics(unit *proto.UnitMetrics, add *proto.ActionMetrics) {
+func (rsrc *raidSimResultCombiner) addActionMetrics(unit *proto.UnitMetrics, add *proto.ActionMetrics, weight float64) {
 	var am *proto.ActionMetrics
 
 	addKey := add.Id.String()
@@ -158,6 +194,11 @@ func (rsrc *raidSimResultCombiner) addActionMetrics(unit *proto.UnitMetrics, add
 			Targets:     make([]*proto.TargetedActionMetrics, len(add.Targets)),
 			SpellSchool: add.SpellSchool,
 		}
+		if add.Dps != nil {
+			am.DamagePerHit = rsrc.newDistMetrics()
+			am.DamagePerCast = rsrc.newDistMetrics()
+			am.Dps = rsrc.newDistMetrics()
+		}
 		for i, addTgt := range add.Targets {
 			am.Targets[i] = &proto.TargetedActionMetrics{
 				UnitIndex: addTgt.UnitIndex,
@@ -205,6 +246,13 @@ func (rsrc *raidSimResultCombiner) addActionMetrics(unit *proto.UnitMetrics, add
 		baseTgt.Shielding += addTgt.Shielding
 		baseTgt.CastTimeMs += addTgt.CastTimeMs
 	}
+
+	// Actions can be missing from the last result, so these are finished in combineUnitMetrics.
+	if add.Dps != nil {
+		rsrc.combineCountedDistMetrics(am.DamagePerHit, add.DamagePerHit)
+		rsrc.combineCountedDistMetrics(am.DamagePerCast, add.DamagePerCast)
+		rsrc.combineDistMetrics(am.Dps, add.Dps, false, weight)
+	}
 }
 
 func (rsrc *raidSimResultCombiner) combineAuraMetrics(base *proto.AuraMetrics, add *proto.AuraMetrics, weight float64, isLast bool) {
@@ -256,9 +304,26 @@ func (rsrc *raidSimResultCombiner) combineUnitMetrics(base *proto.UnitMetrics, a
 
 	base.SecondsOomAvg += add.SecondsOomAvg * weight
 	base.ChanceOfDeath += add.ChanceOfDeath * weight
+	base.SecondsForcedMovementAvg += add.SecondsForcedMovementAvg * weight
+	base.MovementInterruptsAvg += add.MovementInterruptsAvg * weight
+	base.ManaSpentAvg += add.ManaSpentAvg * weight
+	// Healing per mana is a ratio, so this sums the healing and divides by the combined mana at the end.
+	base.HealingPerMana += add.HealingPerMana * add.ManaSpentAvg * weight
+	if isLast && base.ManaSpentAvg > 0 {
+		base.HealingPerMana /= base.ManaSpentAvg
+	}
 
 	for _, addAction := range add.Actions {
-		rsrc.addActionMetrics(base, addAction)
+		rsrc.addActionMetrics(base, addAction, weight)
+	}
+	if isLast {
+		for _, action := range base.Actions {
+			if action.Dps != nil {
+				rsrc.finishDistMetrics(action.DamagePerHit)
+				rsrc.finishDistMetrics(action.DamagePerCast)
+				rsrc.finishDistMetrics(action.Dps)
+			}
+		}
 	}
 
 	for i, addAura := range add.Auras {
@@ -349,6 +414,7 @@ func CombineConcurrentSimResults(results []*proto.RaidSimResult, isDebug bool) *
 		resultWeight := float64(results[i].IterationsDone) / float64(totalIterations)
 		rsrc.AddResult(result, i == numResults-1, resultWeight)
 	}
+	rsrc.Combined.RelativeError = raidMetricsRelativeError(rsrc.Combined.RaidMetrics, totalIterations)
 
 	return rsrc.Combined
 }
@@ -412,6 +478,10 @@ func (csd *concurrentSimData) MakeProgressMetrics() *proto.ProgressMetrics {
 
 // Run sim on multiple threads concurrently by splitting interations over multiple sims, transparently combining results into the progress channel.
 func runSimConcurrent(request *proto.RaidSimRequest, progress chan *proto.ProgressMetrics, signals simsignals.Signals) (result *proto.RaidSimResult) {
+	if isAdaptive(request.SimOptions) {
+		return runSimConcurrentAdaptive(request, progress, signals)
+	}
+
 	defer func() {
 		if !request.SimOptions.IsTest {
 			if err := recover(); err != nil {
@@ -134,8 +134,9 @@ type Spell struct {
 	SpellMetrics []SpellMetrics
 
 	splitIdx          int32
-	splitSpellMetrics [][]SpellMetrics // Used to split metrics by some condition, via SetMetricsSplit
-	splitTags         []int32          // Tags for each splitSpellMetrics used in doneIteration, defaults to the metrics splitIdx.
+	splitSpellMetrics [][]SpellMetrics       // Used to split metrics by some condition, via SetMetricsSplit
+	splitTags         []int32                // Tags for each splitSpellMetrics used in doneIteration, defaults to the metrics splitIdx.
+	distributions     []*actionDistributions // Per metrics split, only set with SimOptions.SaveActionDistributions.
 
 	casts int // Sum of casts on all targets, for efficient CPM calculation
 
@@ -361,7 +362,8 @@ func (unit *Unit) RegisterSpell(config SpellConfig) *Spell {
 		spell.MaxRange = config.MaxRange
 		oldExtraCastCondition := spell.ExtraCastCondition
 		spell.ExtraCastCondition = func(sim *Simulation, target *Unit) bool {
-			if ((spell.MinRange != 0) && (spell.Unit.DistanceFromTarget < spell.MinRange)) || ((spell.MaxRange != 0) && (spell.Unit.DistanceFromTarget > spell.MaxRange)) {
+			distance := spell.Unit.RangeTo(target)
+			if ((spell.MinRange != 0) && (distance < spell.MinRange)) || ((spell.MaxRange != 0) && (distance > spell.MaxRange)) {
 				/*if sim.Log != nil {
 					sim.Log("Cannot cast spell %s, out of range!", spell.ActionID)
 				}*/
@@ -497,7 +499,14 @@ func (spell *Spell) finalize() {
 	}
 }
 
-func (spell *Spell) reset(_ *Simulation) {
+func (spell *Spell) reset(sim *Simulation) {
+	if sim.Options.SaveActionDistributions && spell.distributions == nil && !spell.Flags.Matches(SpellFlagNoMetrics) {
+		spell.distributions = make([]*actionDistributions, len(spell.splitSpellMetrics))
+		for i := range spell.distributions {
+			spell.distributions[i] = spell.Unit.Metrics.actionDistributions(spell.ActionID.WithTag(spell.splitTags[i]))
+		}
+	}
+
 	for i := range spell.splitSpellMetrics {
 		for j := range spell.SpellMetrics {
 			spell.splitSpellMetrics[i][j] = SpellMetrics{}
@@ -578,8 +587,16 @@ func (spell *Spell) CanCast(sim *Simulation, target *Unit) bool {
 		return false
 	}
 
-	// While moving only instant casts are possible
-	if spell.DefaultCast.CastTime > 0 && spell.Unit.IsMoving() {
+	if spell.Unit.isPreventedFromCasting(sim, spell) {
+		//if sim.Log != nil {
+		//	sim.Log("Cant cast because of crowd control")
+		//}
+		return false
+	}
+
+	// While moving only instant casts are possible. Channels are only prevented by encounter
+	// movement events, APL move actions still allow them.
+	if (spell.DefaultCast.CastTime > 0 || (spell.Flags.Matches(SpellFlagChanneled) && spell.Unit.MovementHandler.forced)) && spell.Unit.IsMoving() {
 		//if sim.Log != nil {
 		//	sim.Log("Cant cast because moving")
 		//}
@@ -651,6 +668,9 @@ func (spell *Spell) Apply(sim *Simulation, target *Unit) bool {
 func (spell *Spell) applyEffects(sim *Simulation, target *Unit) {
 	spell.SpellMetrics[target.UnitIndex].Casts++
 	spell.casts++
+	if spell.distributions != nil {
+		spell.distributions[spell.splitIdx].recordCast(sim)
+	}
 
 	// Not sure if we want to split this flag into its own?
 	// Both are used to optimize away unneccesery calls and 99%
@@ -320,9 +320,6 @@ const (
 	// Uses: FloatValue
 	SpellMod_BonusCoeffecient_Flat
 
-	// Enables casting while moving
-	SpellMod_AllowCastWhileMoving
-
 	// Add/subtract bonus spell power
 	// Uses: FloatValue
 	SpellMod_BonusDamage_Flat
@@ -251,7 +251,7 @@ func (spell *Spell) outcomeMeleeWhite(sim *Simulation, result *SpellResult, atta
 	glanceRoll := sim.RandomFloat("White Hit Glancing Penalty")
 	chance := 0.0
 
-	if unit.PseudoStats.InFrontOfTarget {
+	if unit.IsInFrontOf(attackTable.Defender) {
 		if !result.applyAttackTableMiss(spell, attackTable, roll, &chance, countHits) &&
 			!result.applyAttackTableDodge(spell, attackTable, roll, &chance, countHits) &&
 			!result.applyAttackTableParry(spell, attackTable, roll, &chance, countHits) &&
@@ -281,7 +281,7 @@ func (spell *Spell) outcomeMeleeSpecialHit(sim *Simulation, result *SpellResult,
 	roll := sim.RandomFloat("White Hit Table")
 	chance := 0.0
 
-	if unit.PseudoStats.InFrontOfTarget {
+	if unit.IsInFrontOf(attackTable.Defender) {
 		if !result.applyAttackTableMissNoDWPenalty(spell, attackTable, roll, &chance, countHits) &&
 			!result.applyAttackTableDodge(spell, attackTable, roll, &chance, countHits) &&
 			!result.applyAttackTableParry(spell, attackTable, roll, &chance, countHits) {
@@ -306,7 +306,7 @@ func (spell *Spell) outcomeMeleeSpecialHitAndCrit(sim *Simulation, result *Spell
 	roll := sim.RandomFloat("White Hit Table")
 	chance := 0.0
 
-	if unit.PseudoStats.InFrontOfTarget {
+	if unit.IsInFrontOf(attackTable.Defender) {
 		if !result.applyAttackTableMissNoDWPenalty(spell, attackTable, roll, &chance, countHits) &&
 			!result.applyAttackTableDodge(spell, attackTable, roll, &chance, countHits) &&
 			!result.applyAttackTableParry(spell, attackTable, roll, &chance, countHits) {
@@ -336,7 +336,7 @@ func (spell *Spell) OutcomeMeleeWeaponSpecialHitAndCritNoHitCounter(sim *Simulat
 
 // Like OutcomeMeleeSpecialHitAndCrit, but blocks prevent crits (all weapon damage based attacks).
 func (spell *Spell) outcomeMeleeWeaponSpecialHitAndCrit(sim *Simulation, result *SpellResult, attackTable *AttackTable, countHits bool) {
-	if spell.Unit.PseudoStats.InFrontOfTarget {
+	if spell.Unit.IsInFrontOf(attackTable.Defender) {
 		roll := sim.RandomFloat("White Hit Table")
 		chance := 0.0
 
@@ -376,7 +376,7 @@ func (spell *Spell) outcomeMeleeWeaponSpecialNoCrit(sim *Simulation, result *Spe
 	roll := sim.RandomFloat("White Hit Table")
 	chance := 0.0
 
-	if unit.PseudoStats.InFrontOfTarget {
+	if unit.IsInFrontOf(attackTable.Defender) {
 		if !result.applyAttackTableMissNoDWPenalty(spell, attackTable, roll, &chance, countHits) &&
 			!result.applyAttackTableDodge(spell, attackTable, roll, &chance, countHits) &&
 			!result.applyAttackTableParry(spell, attackTable, roll, &chance, countHits) &&
@@ -528,7 +528,7 @@ func (spell *Spell) outcomeRangedHitAndCrit(sim *Simulation, result *SpellResult
 	roll := sim.RandomFloat("White Hit Table")
 	chance := 0.0
 
-	if spell.Unit.PseudoStats.InFrontOfTarget {
+	if spell.Unit.IsInFrontOf(attackTable.Defender) {
 		if !result.applyAttackTableMissNoDWPenalty(spell, attackTable, roll, &chance, countHits) {
 			if result.applyAttackTableCritSeparateRoll(sim, spell, attackTable, countHits) {
 				result.applyAttackTableBlock(spell, attackTable, roll, &chance, countHits)
@@ -556,7 +556,7 @@ func (dot *Dot) outcomeRangedHitAndCritSnapshot(sim *Simulation, result *SpellRe
 	roll := sim.RandomFloat("White Hit Table")
 	chance := 0.0
 
-	if dot.Spell.Unit.PseudoStats.InFrontOfTarget {
+	if dot.Spell.Unit.IsInFrontOf(attackTable.Defender) {
 		if !result.applyAttackTableMissNoDWPenalty(dot.Spell, attackTable, roll, &chance, countHits) {
 			if result.applyAttackTableCritSeparateRollSnapshot(sim, dot, attackTable, countHits) {
 				result.applyAttackTableBlock(dot.Spell, attackTable, roll, &chance, countHits)
@@ -598,7 +598,7 @@ func (spell *Spell) OutcomeRangedCritOnlyNoHitCounter(sim *Simulation, result *S
 }
 func (spell *Spell) outcomeRangedCritOnly(sim *Simulation, result *SpellResult, attackTable *AttackTable, countHits bool) {
 	// Block already checks for this, but we can skip the RNG roll which is expensive.
-	if spell.Unit.PseudoStats.InFrontOfTarget {
+	if spell.Unit.IsInFrontOf(attackTable.Defender) {
 		roll := sim.RandomFloat("White Hit Table")
 		chance := 0.0
 
@@ -646,7 +646,7 @@ func (spell *Spell) fixedCritCheck(sim *Simulation, critChance float64) bool {
 }
 
 func (result *SpellResult) applyAttackTableMiss(spell *Spell, attackTable *AttackTable, roll float64, chance *float64, countHits bool) bool {
-	missChance := attackTable.BaseMissChance - spell.PhysicalHitChance(attackTable)
+	missChance := attackTable.BaseMissChance - spell.PhysicalHitChance(attackTable) + attackTable.pvpDefenseChance()
 	missChance = math.Round(missChance*1000) / 1000
 
 	if spell.Unit.AutoAttacks.IsDualWielding && !spell.Unit.PseudoStats.DisableDWMissPenalty {
@@ -667,7 +667,7 @@ func (result *SpellResult) applyAttackTableMiss(spell *Spell, attackTable *Attac
 }
 
 func (result *SpellResult) applyAttackTableMissNoDWPenalty(spell *Spell, attackTable *AttackTable, roll float64, chance *float64, countHits bool) bool {
-	missChance := attackTable.BaseMissChance - spell.PhysicalHitChance(attackTable)
+	missChance := attackTable.BaseMissChance - spell.PhysicalHitChance(attackTable) + attackTable.pvpDefenseChance()
 	*chance = max(0, missChance)
 
 	if roll < *chance {
@@ -682,7 +682,14 @@ func (result *SpellResult) applyAttackTableMissNoDWPenalty(spell *Spell, attackT
 }
 
 func (result *SpellResult) applyAttackTableBlock(spell *Spell, attackTable *AttackTable, roll float64, chance *float64, countHits bool) bool {
-	*chance += attackTable.BaseBlockChance
+	if attackTable.IsPvP {
+		if !result.Target.PseudoStats.CanBlock || result.Target.PseudoStats.Stunned {
+			return false
+		}
+		*chance += max(0, attackTable.BaseBlockChance+result.Target.stats[stats.Block]/BlockRatingPerBlockChance/100)
+	} else {
+		*chance += attackTable.BaseBlockChance
+	}
 	if roll < *chance {
 		didCrit := result.DidCrit()
 		isPartialResist := result.DidResist()
@@ -713,7 +720,14 @@ func (result *SpellResult) applyAttackTableDodge(spell *Spell, attackTable *Atta
 	// In SoD this works like crit or hit chance.
 	expertiseDodgeReduction := attackTable.Attacker.stats[stats.Expertise] / 100
 
-	*chance += max(0, attackTable.BaseDodgeChance-attackTable.Defender.PseudoStats.DodgeReduction-expertiseDodgeReduction)
+	if attackTable.IsPvP {
+		if result.Target.PseudoStats.Stunned {
+			return false
+		}
+		*chance += max(0, attackTable.BaseDodgeChance+result.Target.GetStat(stats.Dodge)/100-attackTable.Defender.PseudoStats.DodgeReduction-expertiseDodgeReduction)
+	} else {
+		*chance += max(0, attackTable.BaseDodgeChance-attackTable.Defender.PseudoStats.DodgeReduction-expertiseDodgeReduction)
+	}
 	*chance = math.Round(*chance*1000) / 1000
 
 	if roll < *chance {
@@ -731,7 +745,14 @@ func (result *SpellResult) applyAttackTableParry(spell *Spell, attackTable *Atta
 	// In SoD this works like crit or hit chance.
 	expertiseParryReduction := attackTable.Attacker.stats[stats.Expertise] / 100
 
-	*chance += max(0, attackTable.BaseParryChance-expertiseParryReduction)
+	if attackTable.IsPvP {
+		if !result.Target.PseudoStats.CanParry || result.Target.PseudoStats.Stunned {
+			return false
+		}
+		*chance += max(0, attackTable.BaseParryChance+result.Target.GetStat(stats.Parry)/100-expertiseParryReduction)
+	} else {
+		*chance += max(0, attackTable.BaseParryChance-expertiseParryReduction)
+	}
 
 	if roll < *chance {
 		result.Outcome = OutcomeParry
@@ -819,6 +840,14 @@ func (result *SpellResult) applyAttackTableHit(spell *Spell, countHits bool) {
 	}
 }
 
+// Player defenders reduce the chance to be hit with their defense stat in duels, see NewPvPAttackTable.
+func (attackTable *AttackTable) pvpDefenseChance() float64 {
+	if !attackTable.IsPvP {
+		return 0
+	}
+	return attackTable.Defender.stats[stats.Defense] * DefenseRatingToChanceReduction
+}
+
 func (result *SpellResult) applyEnemyAttackTableMiss(spell *Spell, attackTable *AttackTable, roll float64, chance *float64, countHits bool) bool {
 	missChance := attackTable.BaseMissChance + spell.Unit.PseudoStats.IncreasedMissChance +
 		result.Target.stats[stats.Defense]*DefenseRatingToChanceReduction
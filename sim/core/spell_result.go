@@ -443,6 +443,10 @@ func (spell *Spell) dealDamageInternal(sim *Simulation, isPeriodic bool, result
 			spell.SpellMetrics[result.Target.UnitIndex].TotalCrushDamage += result.Damage
 		}
 		spell.SpellMetrics[result.Target.UnitIndex].TotalThreat += result.Threat
+
+		if spell.distributions != nil {
+			spell.distributions[spell.splitIdx].recordDamage(sim, result)
+		}
 	}
 
 	// Mark total damage done in raid so far for health based fights.
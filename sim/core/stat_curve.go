@@ -0,0 +1,163 @@
+package core
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/wowsims/sod/sim/core/proto"
+	"github.com/wowsims/sod/sim/core/simsignals"
+	"github.com/wowsims/sod/sim/core/stats"
+	googleProto "google.golang.org/protobuf/proto"
+)
+
+const defaultStatCurvePoints = 11
+
+// Runs one sim per point of the curve, all with the same seed so that adjacent points can be
+// compared iteration by iteration, and looks for points where the stat loses most of its value.
+func runStatCurve(request *proto.StatCurveRequest, signals simsignals.Signals) *proto.StatCurveResult {
+	numPoints := int(request.NumPoints)
+	if numPoints == 0 {
+		numPoints = defaultStatCurvePoints
+	}
+	if numPoints < 2 {
+		return &proto.StatCurveResult{Error: &proto.ErrorOutcome{Message: "A stat curve needs at least 2 points"}}
+	}
+	if request.MaxValue <= request.MinValue {
+		return &proto.StatCurveResult{Error: &proto.ErrorOutcome{Message: "max_value must be larger than min_value"}}
+	}
+
+	player := googleProto.Clone(request.Player).(*proto.Player)
+	if player.BonusStats == nil {
+		player.BonusStats = &proto.UnitStats{}
+	}
+	if player.BonusStats.Stats == nil {
+		player.BonusStats.Stats = make([]float64, stats.Len)
+	}
+	if player.BonusStats.PseudoStats == nil {
+		player.BonusStats.PseudoStats = make([]float64, stats.PseudoStatsLen)
+	}
+
+	raidProto := SinglePlayerRaidProto(player, request.PartyBuffs, request.RaidBuffs, request.Debuffs)
+	raidProto.Tanks = request.Tanks
+
+	simOptions := googleProto.Clone(request.SimOptions).(*proto.SimOptions)
+	simOptions.SaveAllValues = true
+	simOptions.UseLabeledRands = true
+	if simOptions.RandomSeed == 0 {
+		simOptions.RandomSeed = time.Now().UnixNano()
+	}
+
+	baseRequest := &proto.RaidSimRequest{
+		Raid:       raidProto,
+		Encounter:  request.Encounter,
+		SimOptions: simOptions,
+	}
+
+	simFunc := runSimConcurrent
+	// Don't use go threads in wasm, it just adds more overhead and makes the worker more unresponsive.
+	if IsRunningInWasm() || simOptions.IsTest {
+		simFunc = RunSim
+	}
+
+	stat := stats.UnitStatFromStat(stats.Stat(request.Stat))
+	step := (request.MaxValue - request.MinValue) / float64(numPoints-1)
+
+	result := &proto.StatCurveResult{}
+	var pointValues [][]float64
+	for i := 0; i < numPoints; i++ {
+		value := request.MinValue + float64(i)*step
+		pointRequest := googleProto.Clone(baseRequest).(*proto.RaidSimRequest)
+		stat.AddToStatsProto(pointRequest.Raid.Parties[0].Players[0].BonusStats, value)
+
+		simResult := simFunc(pointRequest, nil, signals)
+		if simResult.Error != nil {
+			return &proto.StatCurveResult{Error: simResult.Error}
+		}
+		// Later points have to run the iterations the first one settled on, so they can be compared per iteration.
+		if i == 0 && isAdaptive(baseRequest.SimOptions) {
+			baseRequest.SimOptions.Iterations = simResult.IterationsDone
+			baseRequest.SimOptions.TargetRelativeError = 0
+		}
+
+		dps := simResult.RaidMetrics.Parties[0].Players[0].Dps
+		confidence := 1.96 * dps.Stdev / math.Sqrt(float64(len(dps.AllValues)))
+		result.Points = append(result.Points, &proto.StatCurvePoint{
+			Value:    value,
+			Dps:      dps.Avg,
+			DpsStdev: dps.Stdev,
+			DpsLow:   dps.Avg - confidence,
+			DpsHigh:  dps.Avg + confidence,
+		})
+		pointValues = append(pointValues, dps.AllValues)
+	}
+
+	segments := make([]statCurveSegment, numPoints-1)
+	for i := range segments {
+		var slope aggregator
+		for j := range pointValues[i] {
+			slope.add((pointValues[i+1][j] - pointValues[i][j]) / step)
+		}
+		mean, stdev := slope.meanAndStdDev()
+		segments[i] = statCurveSegment{slope: mean, stdErr: stdev / math.Sqrt(float64(slope.n))}
+	}
+
+	values := MapSlice(result.Points, func(point *proto.StatCurvePoint) float64 { return point.Value })
+	result.Knees = findStatCurveKnees(values, segments)
+
+	targetLevel := statCurveTargetLevel(request, raidProto)
+	for _, knee := range result.Knees {
+		if knee.IsCap {
+			knee.Description = fmt.Sprintf("%s stops mattering at %+g vs level %d", stats.Stat(request.Stat).StatName(), knee.Value, targetLevel)
+		} else {
+			knee.Description = fmt.Sprintf("%s drops from %.2f to %.2f DPS per point at %+g vs level %d",
+				stats.Stat(request.Stat).StatName(), knee.SlopeBefore, knee.SlopeAfter, knee.Value, targetLevel)
+		}
+	}
+
+	return result
+}
+
+// DPS gained per point of the stat between two adjacent curve points.
+type statCurveSegment struct {
+	slope  float64
+	stdErr float64
+}
+
+// A knee is reported where a significantly positive slope drops by at least half, and the drop
+// is larger than the noise. Runs of consecutive drops are reported once, at their start.
+func findStatCurveKnees(values []float64, segments []statCurveSegment) []*proto.StatCurveKnee {
+	var knees []*proto.StatCurveKnee
+	for i := 1; i < len(segments); i++ {
+		before, after := segments[i-1], segments[i]
+		if before.slope <= 2*before.stdErr {
+			continue
+		}
+
+		drop := before.slope - after.slope
+		if drop < before.slope/2 || drop <= 2*math.Hypot(before.stdErr, after.stdErr) {
+			continue
+		}
+		if len(knees) > 0 && knees[len(knees)-1].Value == values[i-1] {
+			continue
+		}
+
+		knees = append(knees, &proto.StatCurveKnee{
+			Value:       values[i],
+			SlopeBefore: before.slope,
+			SlopeAfter:  after.slope,
+			IsCap:       after.slope <= 2*after.stdErr,
+		})
+	}
+	return knees
+}
+
+func statCurveTargetLevel(request *proto.StatCurveRequest, raidProto *proto.Raid) int32 {
+	if request.Encounter != nil && len(request.Encounter.Targets) > 0 && request.Encounter.Targets[0].Level != 0 {
+		return request.Encounter.Targets[0].Level
+	}
+	if bracket, err := RaidLevelBracket(raidProto); err == nil {
+		return bracket.TargetLevel
+	}
+	return MaxLevelBracket().TargetLevel
+}
@@ -0,0 +1,28 @@
+package core
+
+import "testing"
+
+func TestFindStatCurveKnees(t *testing.T) {
+	values := []float64{0, 2, 4, 6, 8, 10}
+	segments := []statCurveSegment{
+		{slope: 10, stdErr: 0.5},
+		{slope: 10, stdErr: 0.5},
+		{slope: 9.5, stdErr: 0.5},
+		{slope: 0.2, stdErr: 0.5},
+		{slope: 0.1, stdErr: 0.5},
+	}
+
+	knees := findStatCurveKnees(values, segments)
+	if len(knees) != 1 {
+		t.Fatalf("Expected 1 knee, got %d", len(knees))
+	}
+	if knees[0].Value != 6 || !knees[0].IsCap {
+		t.Fatalf("Expected cap at 6, got %v", knees[0])
+	}
+
+	// Noise alone should not produce knees.
+	noisy := []statCurveSegment{{slope: 3, stdErr: 2}, {slope: 0.5, stdErr: 2}}
+	if knees := findStatCurveKnees(values[:3], noisy); len(knees) != 0 {
+		t.Fatalf("Expected no knees for noisy slopes, got %v", knees)
+	}
+}
@@ -49,6 +49,7 @@ type StatWeightValues struct {
 	WeightsStdev  UnitStats
 	EpValues      UnitStats
 	EpValuesStdev UnitStats
+	Interactions  []*proto.StatWeightsInteraction
 }
 
 func NewStatWeightValues() StatWeightValues {
@@ -66,6 +67,7 @@ func (swv *StatWeightValues) ToProto() *proto.StatWeightValues {
 		WeightsStdev:  swv.WeightsStdev.ToProto(),
 		EpValues:      swv.EpValues.ToProto(),
 		EpValuesStdev: swv.EpValuesStdev.ToProto(),
+		Interactions:  swv.Interactions,
 	}
 }
 
@@ -100,7 +102,8 @@ func (swr *StatWeightsResult) ToProto() *proto.StatWeightsResult {
 	}
 }
 
-func buildStatWeightRequests(swr *proto.StatWeightsRequest) *proto.StatWeightRequestsData {
+// Returns the unmodified sim request which all stat weight sims are derived from.
+func statWeightsBaseRequest(swr *proto.StatWeightsRequest) *proto.RaidSimRequest {
 	if swr.Player.BonusStats == nil {
 		swr.Player.BonusStats = &proto.UnitStats{}
 	}
@@ -114,12 +117,6 @@ func buildStatWeightRequests(swr *proto.StatWeightsRequest) *proto.StatWeightReq
 	raidProto := SinglePlayerRaidProto(swr.Player, swr.PartyBuffs, swr.RaidBuffs, swr.Debuffs)
 	raidProto.Tanks = swr.Tanks
 
-	swr.SimOptions.SaveAllValues = true
-
-	// Cut in half since we're doing above and below separately.
-	// This number needs to be the same for the baseline sim too, so that RNG lines up perfectly.
-	swr.SimOptions.Iterations /= 2
-
 	// Make sure an RNG seed is always set because it gives more consistent results.
 	// When there is no user-supplied seed it needs to be a randomly-selected seed
 	// though, so that run-run differences still exist.
@@ -130,40 +127,35 @@ func buildStatWeightRequests(swr *proto.StatWeightsRequest) *proto.StatWeightReq
 	// Reduce variance even more by using test-level RNG controls.
 	swr.SimOptions.UseLabeledRands = true
 
+	return &proto.RaidSimRequest{
+		Raid:       raidProto,
+		Encounter:  swr.Encounter,
+		SimOptions: swr.SimOptions,
+	}
+}
+
+func buildStatWeightRequests(swr *proto.StatWeightsRequest) *proto.StatWeightRequestsData {
+	baseRequest := statWeightsBaseRequest(swr)
+	baseRequest.SimOptions.SaveAllValues = true
+
+	// Cut in half since we're doing above and below separately.
+	// This number needs to be the same for the baseline sim too, so that RNG lines up perfectly.
+	baseRequest.SimOptions.Iterations /= 2
+
 	swBaseResponse := &proto.StatWeightRequestsData{
-		BaseRequest: &proto.RaidSimRequest{
-			Raid:       raidProto,
-			Encounter:  swr.Encounter,
-			SimOptions: swr.SimOptions,
-		},
+		BaseRequest:     baseRequest,
 		EpReferenceStat: swr.EpReferenceStat,
 		StatSimRequests: []*proto.StatWeightsStatRequestData{},
+		Objective:       swr.Objective,
 	}
 
 	// Do half the iterations with a positive, and half with a negative value for better accuracy.
-	const defaultStatMod = 1.0 // lowered for SoD
+	statMods := statWeightMods(swr)
 	statModsLow := make([]float64, stats.UnitStatsLen)
 	statModsHigh := make([]float64, stats.UnitStatsLen)
-
-	// Make sure reference stat is included.
-	statModsLow[swr.EpReferenceStat] = -defaultStatMod
-	statModsHigh[swr.EpReferenceStat] = defaultStatMod
-
-	statsToWeigh := stats.ProtoArrayToStatsList(swr.StatsToWeigh)
-	for _, s := range statsToWeigh {
-		stat := stats.UnitStatFromStat(s)
-		statMod := defaultStatMod
-		if stat.EqualsStat(stats.Armor) || stat.EqualsStat(stats.BonusArmor) || stat.EqualsStat(stats.Mana) {
-			statMod = defaultStatMod * 20
-		}
-		statModsHigh[stat] = statMod
-		statModsLow[stat] = -statMod
-	}
-	for _, s := range swr.PseudoStatsToWeigh {
-		stat := stats.UnitStatFromPseudoStat(s)
-		statMod := 3.0
-		statModsHigh[stat] = statMod
-		statModsLow[stat] = -statMod
+	for i, statMod := range statMods {
+		statModsHigh[i] = statMod
+		statModsLow[i] = -statMod
 	}
 
 	for i := range statModsLow {
@@ -192,6 +184,31 @@ func buildStatWeightRequests(swr *proto.StatWeightsRequest) *proto.StatWeightReq
 	return swBaseResponse
 }
 
+// Returns the amount each weighed stat is changed by, indexed by UnitStat. Unweighed stats are 0.
+func statWeightMods(swr *proto.StatWeightsRequest) []float64 {
+	const defaultStatMod = 1.0 // lowered for SoD
+	statMods := make([]float64, stats.UnitStatsLen)
+
+	// Make sure reference stat is included.
+	statMods[swr.EpReferenceStat] = defaultStatMod
+
+	statsToWeigh := stats.ProtoArrayToStatsList(swr.StatsToWeigh)
+	for _, s := range statsToWeigh {
+		stat := stats.UnitStatFromStat(s)
+		statMod := defaultStatMod
+		if stat.EqualsStat(stats.Armor) || stat.EqualsStat(stats.BonusArmor) || stat.EqualsStat(stats.Mana) {
+			statMod = defaultStatMod * 20
+		}
+		statMods[stat] = statMod
+	}
+	for _, s := range swr.PseudoStatsToWeigh {
+		stat := stats.UnitStatFromPseudoStat(s)
+		statMods[stat] = 3.0
+	}
+
+	return statMods
+}
+
 func computeStatWeights(swcr *proto.StatWeightsCalcRequest) *proto.StatWeightsResult {
 	haveRefStat := false
 	for _, statResult := range swcr.StatSimResults {
@@ -203,6 +220,9 @@ func computeStatWeights(swcr *proto.StatWeightsCalcRequest) *proto.StatWeightsRe
 	if !haveRefStat {
 		return &proto.StatWeightsResult{Error: &proto.ErrorOutcome{Message: "No result for reference stat exists!"}}
 	}
+	if err := validateObjective(swcr.Objective); err != nil {
+		return &proto.StatWeightsResult{Error: &proto.ErrorOutcome{Message: err.Error()}}
+	}
 
 	result := NewStatWeightsResult()
 	for _, statResult := range swcr.StatSimResults {
@@ -234,7 +254,14 @@ func computeStatWeights(swcr *proto.StatWeightsCalcRequest) *proto.StatWeightsRe
 			weightResults.WeightsStdev.AddStat(stat, stdev)
 		}
 
-		calcWeightResults(baselinePlayer.Dps, modPlayerLow.Dps, modPlayerHigh.Dps, &result.Dps)
+		if swcr.Objective.GetType() == proto.OptimizationObjectiveType_ObjectiveMean {
+			calcWeightResults(baselinePlayer.Dps, modPlayerLow.Dps, modPlayerHigh.Dps, &result.Dps)
+		} else {
+			meanLow, stdevLow := objectiveDelta(swcr.Objective, baselinePlayer.Dps.AllValues, modPlayerLow.Dps.AllValues, statResult.StatData.ModLow)
+			meanHigh, stdevHigh := objectiveDelta(swcr.Objective, baselinePlayer.Dps.AllValues, modPlayerHigh.Dps.AllValues, statResult.StatData.ModHigh)
+			result.Dps.Weights.AddStat(stat, (meanLow+meanHigh)/2)
+			result.Dps.WeightsStdev.AddStat(stat, math.Hypot(stdevLow, stdevHigh)/2)
+		}
 		calcWeightResults(baselinePlayer.Hps, modPlayerLow.Hps, modPlayerHigh.Hps, &result.Hps)
 		calcWeightResults(baselinePlayer.Threat, modPlayerLow.Threat, modPlayerHigh.Threat, &result.Tps)
 		calcWeightResults(baselinePlayer.Dtps, modPlayerLow.Dtps, modPlayerHigh.Dtps, &result.Dtps)
@@ -245,12 +272,16 @@ func computeStatWeights(swcr *proto.StatWeightsCalcRequest) *proto.StatWeightsRe
 		result.PDeath.WeightsStdev.AddStat(stat, 0)
 	}
 
-	referenceStat := stats.Stat(swcr.EpReferenceStat)
+	weighedStats := MapSlice(swcr.StatSimResults, func(statResult *proto.StatWeightsStatResultData) stats.UnitStat {
+		return stats.UnitStatFromIdx(int(statResult.StatData.UnitStat))
+	})
+	result.computeEpValues(weighedStats, stats.Stat(swcr.EpReferenceStat))
 
-	// Compute EP results.
-	for _, statData := range swcr.StatSimResults {
-		stat := stats.UnitStatFromIdx(int(statData.StatData.UnitStat))
+	return result.ToProto()
+}
 
+func (result *StatWeightsResult) computeEpValues(weighedStats []stats.UnitStat, referenceStat stats.Stat) {
+	for _, stat := range weighedStats {
 		calcEpResults := func(weightResults *StatWeightValues, refStat stats.Stat) {
 			if weightResults.Weights.Stats[refStat] == 0 {
 				return
@@ -268,12 +299,18 @@ func computeStatWeights(swcr *proto.StatWeightsCalcRequest) *proto.StatWeightsRe
 		calcEpResults(&result.Tmi, DTPSReferenceStat)
 		calcEpResults(&result.PDeath, DTPSReferenceStat)
 	}
-
-	return result.ToProto()
 }
 
 // Run stat weight sims and compute weights.
 func runStatWeights(request *proto.StatWeightsRequest, progress chan *proto.ProgressMetrics, signals simsignals.Signals) *proto.StatWeightsResult {
+	if err := validateObjective(request.Objective); err != nil {
+		return &proto.StatWeightsResult{Error: &proto.ErrorOutcome{Message: err.Error()}}
+	}
+
+	if request.Method == proto.StatWeightsMethod_StatWeightsMethodRegression {
+		return runRegressionStatWeights(request, progress, signals)
+	}
+
 	requestData := buildStatWeightRequests(request)
 
 	var iterationsTotal int32 = requestData.BaseRequest.SimOptions.Iterations
@@ -323,6 +360,19 @@ func runStatWeights(request *proto.StatWeightsRequest, progress chan *proto.Prog
 		return &proto.StatWeightsResult{Error: baselineResult.Error}
 	}
 
+	// Per-iteration values of the stat sims are compared against the baseline, so they have to
+	// run exactly the iterations the baseline settled on.
+	if isAdaptive(requestData.BaseRequest.SimOptions) {
+		iterationsTotal = baselineResult.IterationsDone
+		for _, reqData := range requestData.StatSimRequests {
+			for _, statRequest := range []*proto.RaidSimRequest{reqData.RequestLow, reqData.RequestHigh} {
+				statRequest.SimOptions.Iterations = baselineResult.IterationsDone
+				statRequest.SimOptions.TargetRelativeError = 0
+				iterationsTotal += baselineResult.IterationsDone
+			}
+		}
+	}
+
 	statResults := []*proto.StatWeightsStatResultData{}
 
 	for _, reqData := range requestData.StatSimRequests {
@@ -351,5 +401,6 @@ func runStatWeights(request *proto.StatWeightsRequest, progress chan *proto.Prog
 		BaseResult:      baselineResult,
 		EpReferenceStat: requestData.EpReferenceStat,
 		StatSimResults:  statResults,
+		Objective:       requestData.Objective,
 	})
 }
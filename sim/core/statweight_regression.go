@@ -0,0 +1,265 @@
+package core
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+
+	"github.com/wowsims/sod/sim/core/proto"
+	"github.com/wowsims/sod/sim/core/simsignals"
+	"github.com/wowsims/sod/sim/core/stats"
+	googleProto "google.golang.org/protobuf/proto"
+)
+
+// Perturbations are drawn from [-scale*mod, scale*mod], where mod is the finite difference step of the stat.
+// A wider range than the finite difference step gives the fit a usable signal to noise ratio.
+const regressionStatModScale = 5.0
+
+// Computes stat weights from sims where all weighed stats are randomly perturbed at once.
+// Each weight is the linear coefficient of a least squares fit of the metric on the
+// perturbations, so the number of sims doesn't grow with every weighed stat.
+// Quadratic fits additionally return squared and pairwise interaction terms.
+func runRegressionStatWeights(request *proto.StatWeightsRequest, progress chan *proto.ProgressMetrics, signals simsignals.Signals) *proto.StatWeightsResult {
+	statMods := statWeightMods(request)
+	var weighedStats []stats.UnitStat
+	for i, statMod := range statMods {
+		if statMod != 0 {
+			weighedStats = append(weighedStats, stats.UnitStatFromIdx(i))
+		}
+	}
+
+	numTerms := 1 + len(weighedStats)
+	if request.RegressionQuadratic {
+		numTerms += len(weighedStats) * (len(weighedStats) + 1) / 2
+	}
+	numBatches := int(request.RegressionBatches)
+	if numBatches == 0 {
+		numBatches = 2 * numTerms
+	}
+	if numBatches <= numTerms {
+		return &proto.StatWeightsResult{Error: &proto.ErrorOutcome{
+			Message: fmt.Sprintf("At least %d regression batches are needed to fit %d coefficients", numTerms+1, numTerms),
+		}}
+	}
+
+	baseRequest := statWeightsBaseRequest(request)
+	baseRequest.SimOptions.Iterations = max(baseRequest.SimOptions.Iterations/int32(numBatches), 1)
+	if objectiveNeedsAllValues(request.Objective) {
+		baseRequest.SimOptions.SaveAllValues = true
+	}
+
+	// All batches share the base seed, so RNG lines up between them and only the stat changes add variance.
+	rng := rand.New(rand.NewSource(baseRequest.SimOptions.RandomSeed))
+	perturbations := make([][]float64, numBatches)
+	batchRequests := make([]*proto.RaidSimRequest, numBatches)
+	for i := range batchRequests {
+		perturbations[i] = make([]float64, len(weighedStats))
+		batchRequests[i] = googleProto.Clone(baseRequest).(*proto.RaidSimRequest)
+		for j, stat := range weighedStats {
+			statRange := statMods[stat] * regressionStatModScale
+			perturbations[i][j] = (rng.Float64()*2 - 1) * statRange
+			stat.AddToStatsProto(batchRequests[i].Raid.Parties[0].Players[0].BonusStats, perturbations[i][j])
+		}
+	}
+
+	simFunc := runSimConcurrent
+	// Don't use go threads in wasm, it just adds more overhead and makes the worker more unresponsive.
+	if IsRunningInWasm() || request.SimOptions.IsTest {
+		simFunc = RunSim
+	}
+
+	iterationsTotal := baseRequest.SimOptions.Iterations * int32(numBatches)
+	var iterationsDone int32 = 0
+	batchResults := make([]*proto.UnitMetrics, numBatches)
+	for i, batchRequest := range batchRequests {
+		batchProgress := make(chan *proto.ProgressMetrics, 100)
+		go simFunc(batchRequest, batchProgress, signals)
+
+		var result *proto.RaidSimResult
+		var lastCompleted int32 = 0
+		for metrics := range batchProgress {
+			iterationsDone += metrics.CompletedIterations - lastCompleted
+			lastCompleted = metrics.CompletedIterations
+
+			if progress != nil {
+				progress <- &proto.ProgressMetrics{
+					TotalIterations:     iterationsTotal,
+					CompletedIterations: iterationsDone,
+					CompletedSims:       int32(i),
+					TotalSims:           int32(numBatches),
+				}
+			}
+
+			if metrics.FinalRaidResult != nil {
+				result = metrics.FinalRaidResult
+				break
+			}
+		}
+		if result == nil {
+			return &proto.StatWeightsResult{Error: &proto.ErrorOutcome{Message: "Regression batch sim did not return a result"}}
+		}
+		if result.Error != nil {
+			return &proto.StatWeightsResult{Error: result.Error}
+		}
+		batchResults[i] = result.RaidMetrics.Parties[0].Players[0]
+	}
+
+	design := MapSlice(perturbations, func(x []float64) []float64 {
+		return regressionDesignRow(x, request.RegressionQuadratic)
+	})
+
+	result := NewStatWeightsResult()
+	fitMetric := func(metric func(*proto.UnitMetrics) float64, weightResults *StatWeightValues) error {
+		coefficients, stdevs, err := fitLeastSquares(design, MapSlice(batchResults, metric))
+		if err != nil {
+			return err
+		}
+
+		for i, stat := range weighedStats {
+			weightResults.Weights.AddStat(stat, coefficients[1+i])
+			weightResults.WeightsStdev.AddStat(stat, stdevs[1+i])
+		}
+
+		if request.RegressionQuadratic {
+			term := 1 + len(weighedStats)
+			for i, statA := range weighedStats {
+				for _, statB := range weighedStats[i:] {
+					weightResults.Interactions = append(weightResults.Interactions, &proto.StatWeightsInteraction{
+						UnitStatA:        int32(statA),
+						UnitStatB:        int32(statB),
+						Coefficient:      coefficients[term],
+						CoefficientStdev: stdevs[term],
+					})
+					term++
+				}
+			}
+		}
+		return nil
+	}
+
+	for _, fit := range []struct {
+		metric        func(*proto.UnitMetrics) float64
+		weightResults *StatWeightValues
+	}{
+		{func(m *proto.UnitMetrics) float64 { return objectiveScore(request.Objective, m.Dps) }, &result.Dps},
+		{func(m *proto.UnitMetrics) float64 { return m.Hps.Avg }, &result.Hps},
+		{func(m *proto.UnitMetrics) float64 { return m.Threat.Avg }, &result.Tps},
+		{func(m *proto.UnitMetrics) float64 { return m.Dtps.Avg }, &result.Dtps},
+		{func(m *proto.UnitMetrics) float64 { return m.Tmi.Avg }, &result.Tmi},
+		{func(m *proto.UnitMetrics) float64 { return m.ChanceOfDeath }, &result.PDeath},
+	} {
+		if err := fitMetric(fit.metric, fit.weightResults); err != nil {
+			return &proto.StatWeightsResult{Error: &proto.ErrorOutcome{Message: err.Error()}}
+		}
+	}
+
+	result.computeEpValues(weighedStats, stats.Stat(request.EpReferenceStat))
+	return result.ToProto()
+}
+
+// Intercept, linear terms and optionally all squared and pairwise products.
+func regressionDesignRow(x []float64, quadratic bool) []float64 {
+	row := append([]float64{1}, x...)
+	if quadratic {
+		for i := range x {
+			for j := i; j < len(x); j++ {
+				row = append(row, x[i]*x[j])
+			}
+		}
+	}
+	return row
+}
+
+// Ordinary least squares fit of y = X*b. Returns the coefficients and their standard errors.
+func fitLeastSquares(x [][]float64, y []float64) ([]float64, []float64, error) {
+	n, p := len(x), len(x[0])
+	if n <= p {
+		return nil, nil, fmt.Errorf("need more than %d samples to fit %d coefficients, have %d", p, p, n)
+	}
+
+	xtx := make([][]float64, p)
+	xty := make([]float64, p)
+	for i := range xtx {
+		xtx[i] = make([]float64, p)
+	}
+	for row := range x {
+		for i := 0; i < p; i++ {
+			xty[i] += x[row][i] * y[row]
+			for j := 0; j < p; j++ {
+				xtx[i][j] += x[row][i] * x[row][j]
+			}
+		}
+	}
+
+	xtxInv, ok := invertMatrix(xtx)
+	if !ok {
+		return nil, nil, fmt.Errorf("regression is singular, stat perturbations are not independent")
+	}
+
+	coefficients := make([]float64, p)
+	for i := range coefficients {
+		for j := 0; j < p; j++ {
+			coefficients[i] += xtxInv[i][j] * xty[j]
+		}
+	}
+
+	residualSumSq := 0.0
+	for row := range x {
+		predicted := 0.0
+		for i := 0; i < p; i++ {
+			predicted += x[row][i] * coefficients[i]
+		}
+		residualSumSq += (y[row] - predicted) * (y[row] - predicted)
+	}
+	variance := residualSumSq / float64(n-p)
+
+	stdevs := make([]float64, p)
+	for i := range stdevs {
+		stdevs[i] = math.Sqrt(variance * xtxInv[i][i])
+	}
+	return coefficients, stdevs, nil
+}
+
+// Gauss-Jordan elimination with partial pivoting. Returns false if the matrix is singular.
+func invertMatrix(m [][]float64) ([][]float64, bool) {
+	size := len(m)
+	a := make([][]float64, size)
+	for i := range a {
+		a[i] = make([]float64, 2*size)
+		copy(a[i], m[i])
+		a[i][size+i] = 1
+	}
+
+	for col := 0; col < size; col++ {
+		pivot := col
+		for row := col + 1; row < size; row++ {
+			if math.Abs(a[row][col]) > math.Abs(a[pivot][col]) {
+				pivot = row
+			}
+		}
+		if math.Abs(a[pivot][col]) < 1e-12 {
+			return nil, false
+		}
+		a[col], a[pivot] = a[pivot], a[col]
+
+		pivotValue := a[col][col]
+		for j := range a[col] {
+			a[col][j] /= pivotValue
+		}
+		for row := 0; row < size; row++ {
+			if row == col || a[row][col] == 0 {
+				continue
+			}
+			factor := a[row][col]
+			for j := range a[row] {
+				a[row][j] -= factor * a[col][j]
+			}
+		}
+	}
+
+	inverse := make([][]float64, size)
+	for i := range inverse {
+		inverse[i] = a[i][size:]
+	}
+	return inverse, true
+}
@@ -0,0 +1,38 @@
+package core
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestFitLeastSquaresQuadratic(t *testing.T) {
+	// y = 100 + 3a - 2b + 0.5a^2 - 0.25ab, with a little noise.
+	rng := rand.New(rand.NewSource(1))
+	var design [][]float64
+	var y []float64
+	for i := 0; i < 50; i++ {
+		a, b := rng.Float64()*10-5, rng.Float64()*10-5
+		design = append(design, regressionDesignRow([]float64{a, b}, true))
+		y = append(y, 100+3*a-2*b+0.5*a*a-0.25*a*b+(rng.Float64()-0.5)*0.01)
+	}
+
+	coefficients, stdevs, err := fitLeastSquares(design, y)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Terms are [1, a, b, a^2, ab, b^2].
+	expected := []float64{100, 3, -2, 0.5, -0.25, 0}
+	for i, want := range expected {
+		if !WithinToleranceFloat64(want, coefficients[i], 0.01) {
+			t.Fatalf("Coefficient %d: expected %0.3f, got %0.3f", i, want, coefficients[i])
+		}
+		if stdevs[i] > 0.01 {
+			t.Fatalf("Coefficient %d: standard error %0.4f is too large", i, stdevs[i])
+		}
+	}
+
+	if _, _, err := fitLeastSquares(design[:5], y[:5]); err == nil {
+		t.Fatalf("Expected error with fewer samples than coefficients")
+	}
+}
@@ -29,7 +29,7 @@ type Encounter struct {
 	aoeCapMultiplier float64
 }
 
-func NewEncounter(options *proto.Encounter) Encounter {
+func NewEncounter(options *proto.Encounter, levelBracket LevelBracket) Encounter {
 	options.ExecuteProportion_25 = max(options.ExecuteProportion_25, options.ExecuteProportion_20)
 	options.ExecuteProportion_35 = max(options.ExecuteProportion_35, options.ExecuteProportion_25)
 
@@ -52,14 +52,14 @@ func NewEncounter(options *proto.Encounter) Encounter {
 	}
 
 	for targetIndex, targetOptions := range options.Targets {
-		target := NewTarget(targetOptions, int32(targetIndex))
+		target := NewTarget(targetOptions, int32(targetIndex), levelBracket.TargetLevel)
 		encounter.Targets = append(encounter.Targets, target)
 		encounter.TargetUnits = append(encounter.TargetUnits, &target.Unit)
 	}
 	if len(encounter.Targets) == 0 {
 		// Add a dummy target. The only case where targets aren't specified is when
 		// computing character stats, and targets won't matter there.
-		target := NewTarget(&proto.Target{}, 0)
+		target := NewTarget(&proto.Target{}, 0, levelBracket.TargetLevel)
 		encounter.Targets = append(encounter.Targets, target)
 		encounter.TargetUnits = append(encounter.TargetUnits, &target.Unit)
 	}
@@ -110,7 +110,7 @@ type Target struct {
 	AI TargetAI
 }
 
-func NewTarget(options *proto.Target, targetIndex int32) *Target {
+func NewTarget(options *proto.Target, targetIndex int32, defaultLevel int32) *Target {
 	unitStats := stats.Stats{}
 	if options.Stats != nil {
 		copy(unitStats[:], options.Stats)
@@ -131,10 +131,14 @@ func NewTarget(options *proto.Target, targetIndex int32) *Target {
 			StatDependencyManager: stats.NewStatDependencyManager(),
 		},
 	}
-	defaultRaidBossLevel := int32(CharacterMaxLevel + 3)
 	target.GCD = target.NewTimer()
+	target.Facing = DirectionFromDegrees(options.FacingDegrees)
+	if options.Position != nil {
+		position := Vector2FromProto(options.Position)
+		target.startPosition = &position
+	}
 	if target.Level == 0 {
-		target.Level = defaultRaidBossLevel
+		target.Level = defaultLevel
 	}
 
 	target.AddStatDependency(stats.Defense, stats.Dodge, MissDodgeParryBlockCritChancePerDefense)
@@ -280,6 +284,9 @@ type AttackTable struct {
 	// This is for "Apply Aura: Mod Damage Done By Caster" effects.
 	// If set, the damage taken multiplier is multiplied by the callbacks result.
 	DamageDoneByCasterMultiplier func(spell *Spell, attackTable *AttackTable) float64
+
+	// Whether both units are players fighting each other, see NewPvPAttackTable.
+	IsPvP bool
 }
 
 func NewAttackTable(attacker *Unit, defender *Unit, weapon *Item) *AttackTable {
@@ -368,6 +375,39 @@ func NewAttackTable(attacker *Unit, defender *Unit, weapon *Item) *AttackTable {
 	return table
 }
 
+// Attack table between two players in a duel. Player defenders add their defense, dodge, parry
+// and block like they do against NPC attacks, and there are no glancing blows or crushing blows.
+// Classic has no resilience, so crits hit players just as hard as NPCs.
+func NewPvPAttackTable(attacker *Unit, defender *Unit, weapon *Item) *AttackTable {
+	table := &AttackTable{
+		Attacker: attacker,
+		Defender: defender,
+		Weapon:   weapon,
+
+		CritMultiplier: 1,
+
+		DamageDealtMultiplier:  1,
+		DamageTakenMultiplier:  1,
+		HealingDealtMultiplier: 1,
+
+		IsPvP: true,
+	}
+
+	weaponSkill := float64(attacker.Level*5) + GetWeaponSkill(attacker, weapon)
+	defenseSkill := float64(defender.Level * 5)
+	skillDelta := (defenseSkill - weaponSkill) * MissDodgeParryBlockCritChancePerDefense / 100
+
+	table.BaseMissChance = 0.05 + skillDelta
+	table.BaseDodgeChance = skillDelta // base dodge applied with class base stats
+	table.BaseParryChance = skillDelta // + 0.05 applied as stats in character.go
+	table.BaseBlockChance = skillDelta // + 0.05 applied as stats in character.go
+	table.MeleeCritSuppression = skillDelta
+
+	table.BaseSpellMissChance = UnitLevelFloat64(defender.Level-attacker.Level, 0.04, 0.05, 0.06, 0.13)
+
+	return table
+}
+
 func ModNonMeleeAttackTable(table *AttackTable, attacker *Unit, defender *Unit, weapon *Item) {
 	weaponSkill := float64(attacker.Level*5) + float64(GetWeaponSkill(attacker, weapon))
 
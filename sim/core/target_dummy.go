@@ -11,14 +11,14 @@ type TargetDummy struct {
 	Character
 }
 
-func NewTargetDummy(dummyIndex int, party *Party, partyIndex int) *TargetDummy {
+func NewTargetDummy(dummyIndex int, party *Party, partyIndex int, level int32) *TargetDummy {
 	name := fmt.Sprintf("Target Dummy %d", dummyIndex+1)
 	td := &TargetDummy{
 		Character: Character{
 			Unit: Unit{
 				Type:        PlayerUnit,
 				Index:       int32(party.Index*5 + partyIndex),
-				Level:       CharacterMaxLevel,
+				Level:       level,
 				PseudoStats: stats.NewPseudoStats(),
 				auraTracker: newAuraTracker(),
 				Metrics:     NewUnitMetrics(),
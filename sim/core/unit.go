@@ -56,11 +56,20 @@ type Unit struct {
 	// Amount of time following a post-GCD channel tick, to when the next action can be performed.
 	ChannelClipDelay time.Duration
 
+	// Network latency and spell queue window of the human agent, see latency.go.
+	Latency Latency
+
 	// How far this unit is from its target(s). Measured in yards, this is used
 	// for calculating spell travel time for certain spells.
 	StartDistanceFromTarget float64
 	DistanceFromTarget      float64
 
+	// Position on the ground and facing direction, see position.go. startPosition is nil
+	// unless the unit was placed explicitly.
+	Position      Vector2
+	Facing        Vector2
+	startPosition *Vector2
+
 	MovementHandler *MovementHandler
 
 	// Environment in which this Unit exists. This will be nil until after the
@@ -142,6 +151,8 @@ type Unit struct {
 	// GCD-related PendingActions.
 	gcdAction      *PendingAction
 	hardcastAction *PendingAction
+	// Latency added to the GCD of the current cast, see nextCastDelay.
+	castDelay time.Duration
 
 	// Cached mana return values per tick.
 	manaTickWhileCasting    float64
@@ -154,6 +165,11 @@ type Unit struct {
 
 	// The currently-channeled DOT spell, otherwise nil.
 	ChanneledDot *Dot
+
+	// Side of the unit in a duel, 0 outside of duels. See duel.go.
+	duelTeam int32
+	// Only set for units which can be crowd controlled, see crowd_control.go.
+	crowdControl *crowdControl
 }
 
 // Units can be disabled for several reasons:
@@ -169,10 +185,13 @@ func (unit *Unit) IsActive() bool {
 }
 
 func (unit *Unit) IsOpponent(other *Unit) bool {
-	return (unit.Type == EnemyUnit) != (other.Type == EnemyUnit)
+	return (unit.Type == EnemyUnit) != (other.Type == EnemyUnit) || unit.isDuelOpponent(other)
 }
 
 func (unit *Unit) GetOpponents() []*Unit {
+	if unit.duelTeam != 0 {
+		return unit.Env.duel.opponentUnits(unit.duelTeam)
+	}
 	if unit.Type == EnemyUnit {
 		return unit.Env.Raid.AllUnits
 	} else {
@@ -536,6 +555,7 @@ func (unit *Unit) reset(sim *Simulation, _ Agent) {
 	}
 
 	unit.DistanceFromTarget = unit.StartDistanceFromTarget
+	unit.resetPosition()
 
 	unit.manaBar.reset()
 	unit.focusBar.reset(sim)
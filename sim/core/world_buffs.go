@@ -0,0 +1,147 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wowsims/sod/sim/core/proto"
+	googleProto "google.golang.org/protobuf/proto"
+)
+
+// World buffs which can be given a timing, keyed by IndividualBuffs field name.
+type worldBuff struct {
+	labels   []string
+	duration time.Duration
+}
+
+var worldBuffs = map[string]worldBuff{
+	"rallying_cry_of_the_dragonslayer": {labels: []string{"Rallying Cry of the Dragonslayer"}, duration: time.Hour * 2},
+	"valor_of_azeroth":                 {labels: []string{"Valor of Azeroth"}, duration: time.Hour * 2},
+	"sayges_fortune": {
+		labels: []string{
+			"Sayge's Dark Fortune of Damage",
+			"Sayge's Dark Fortune of Agility",
+			"Sayge's Dark Fortune of Intellect",
+			"Sayge's Dark Fortune of Spirit",
+			"Sayge's Dark Fortune of Stamina",
+		},
+		duration: time.Hour * 2,
+	},
+	"spirit_of_zandalar":            {labels: []string{"Spirit of Zandalar"}, duration: time.Hour * 2},
+	"songflower_serenade":           {labels: []string{"Songflower Serenade"}, duration: time.Hour},
+	"warchiefs_blessing":            {labels: []string{"Warchief's Blessing"}, duration: time.Hour},
+	"might_of_stormwind":            {labels: []string{"Might of Stormwind"}, duration: time.Hour},
+	"fengus_ferocity":               {labels: []string{"Fengus' Ferocity"}, duration: time.Hour * 2},
+	"moldars_moxie":                 {labels: []string{"Moldar's Moxie"}, duration: time.Hour * 2},
+	"slipkiks_savvy":                {labels: []string{"Slip'kik's Savvy"}, duration: time.Hour * 2},
+	"boon_of_blackfathom":           {labels: []string{"Boon of Blackfathom"}, duration: time.Hour * 2},
+	"ashenvale_pvp_buff":            {labels: []string{"Ashenvale Rallying Cry"}, duration: time.Hour * 2},
+	"spark_of_inspiration":          {labels: []string{"Spark of Inspiration"}, duration: time.Hour * 2},
+	"fervor_of_the_temple_explorer": {labels: []string{"Fervor of the Temple Explorer"}, duration: time.Hour * 2},
+}
+
+func validateWorldBuffTiming(timing *proto.WorldBuffTiming) error {
+	if _, ok := worldBuffs[timing.Buff]; !ok {
+		return fmt.Errorf("world buff timing for unknown world buff %q", timing.Buff)
+	}
+	if timing.RemainingSeconds < 0 || timing.DropSeconds < 0 {
+		return fmt.Errorf("world buff timing for %s: remaining duration and drop time can't be negative", timing.Buff)
+	}
+	return nil
+}
+
+// Time after pull at which the buff ends, or NeverExpires.
+func worldBuffExpiry(timing *proto.WorldBuffTiming) time.Duration {
+	expiresAt := NeverExpires
+	if timing.RemainingSeconds > 0 {
+		expiresAt = DurationFromSeconds(timing.RemainingSeconds)
+	}
+	if timing.DropSeconds > 0 {
+		expiresAt = min(expiresAt, DurationFromSeconds(timing.DropSeconds))
+	}
+	return expiresAt
+}
+
+// Removes the world buffs of the unit once they run out or are dropped. Must be called after
+// the world buff auras are registered.
+func applyWorldBuffTimings(unit *Unit, individualBuffs *proto.IndividualBuffs) {
+	for _, timing := range individualBuffs.WorldBuffTimings {
+		if err := validateWorldBuffTiming(timing); err != nil {
+			panic(err)
+		}
+		expiresAt := worldBuffExpiry(timing)
+		if expiresAt == NeverExpires {
+			continue
+		}
+
+		for _, label := range worldBuffs[timing.Buff].labels {
+			aura := unit.GetAura(label)
+			if aura == nil {
+				continue
+			}
+			unit.RegisterResetEffect(func(sim *Simulation) {
+				StartDelayedAction(sim, DelayedActionOptions{
+					DoAt:     expiresAt,
+					OnAction: aura.Deactivate,
+				})
+			})
+		}
+	}
+}
+
+// World buffs of a player at a pull, the given time after the first world buff was gained.
+// Buffs without a timing start out fresh. Buffs which ran out or were dropped before the pull
+// are turned off.
+func worldBuffsAtPull(individualBuffs *proto.IndividualBuffs, elapsed time.Duration) (*proto.IndividualBuffs, error) {
+	timings := make(map[string]*proto.WorldBuffTiming, len(individualBuffs.GetWorldBuffTimings()))
+	for _, timing := range individualBuffs.GetWorldBuffTimings() {
+		if err := validateWorldBuffTiming(timing); err != nil {
+			return nil, err
+		}
+		timings[timing.Buff] = timing
+	}
+
+	atPull := &proto.IndividualBuffs{}
+	if individualBuffs != nil {
+		atPull = googleProto.Clone(individualBuffs).(*proto.IndividualBuffs)
+	}
+	atPull.WorldBuffTimings = nil
+
+	msg := atPull.ProtoReflect()
+	fields := msg.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		buff, ok := worldBuffs[string(field.Name())]
+		if !ok || !msg.Has(field) {
+			continue
+		}
+
+		remaining := buff.duration
+		dropAt := time.Duration(0)
+		if timing := timings[string(field.Name())]; timing != nil {
+			if timing.RemainingSeconds > 0 {
+				remaining = DurationFromSeconds(timing.RemainingSeconds)
+			}
+			dropAt = DurationFromSeconds(timing.DropSeconds)
+		}
+
+		remaining -= elapsed
+		if dropAt > 0 {
+			dropAt -= elapsed
+			if dropAt <= 0 {
+				remaining = 0
+			}
+		}
+		if remaining <= 0 {
+			msg.Clear(field)
+			continue
+		}
+
+		atPull.WorldBuffTimings = append(atPull.WorldBuffTimings, &proto.WorldBuffTiming{
+			Buff:             string(field.Name()),
+			RemainingSeconds: remaining.Seconds(),
+			DropSeconds:      dropAt.Seconds(),
+		})
+	}
+	return atPull, nil
+}
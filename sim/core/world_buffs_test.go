@@ -0,0 +1,54 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+func TestWorldBuffsAtPull(t *testing.T) {
+	buffs := &proto.IndividualBuffs{
+		RallyingCryOfTheDragonslayer: true,
+		SongflowerSerenade:           true,
+		SpiritOfZandalar:             true,
+		BlessingOfKings:              true,
+		WorldBuffTimings: []*proto.WorldBuffTiming{
+			{Buff: "rallying_cry_of_the_dragonslayer", RemainingSeconds: 1800},
+			{Buff: "spirit_of_zandalar", DropSeconds: 4000},
+		},
+	}
+
+	atPull, err := worldBuffsAtPull(buffs, time.Minute*20)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	timings := map[string]*proto.WorldBuffTiming{}
+	for _, timing := range atPull.WorldBuffTimings {
+		timings[timing.Buff] = timing
+	}
+	if timing := timings["rallying_cry_of_the_dragonslayer"]; timing == nil || timing.RemainingSeconds != 600 {
+		t.Fatalf("Expected 600s of Rallying Cry left, got %v", timing)
+	}
+	if timing := timings["songflower_serenade"]; timing == nil || timing.RemainingSeconds != 2400 {
+		t.Fatalf("Expected 2400s of a fresh Songflower Serenade left, got %v", timing)
+	}
+	if timing := timings["spirit_of_zandalar"]; timing == nil || timing.DropSeconds != 2800 {
+		t.Fatalf("Expected Spirit of Zandalar to drop 2800s after pull, got %v", timing)
+	}
+
+	atPull, err = worldBuffsAtPull(buffs, time.Minute*70)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if atPull.RallyingCryOfTheDragonslayer || atPull.SongflowerSerenade || atPull.SpiritOfZandalar {
+		t.Fatalf("Expected expired and dropped world buffs to be turned off, got %v", atPull)
+	}
+	if !atPull.BlessingOfKings {
+		t.Fatalf("Expected other buffs to be kept")
+	}
+
+	if _, err := worldBuffsAtPull(&proto.IndividualBuffs{WorldBuffTimings: []*proto.WorldBuffTiming{{Buff: "blessing_of_kings"}}}, 0); err == nil {
+		t.Fatalf("Expected an error for a timing of a buff which isn't a world buff")
+	}
+}
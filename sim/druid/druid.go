@@ -25,14 +25,18 @@ const (
 	ClassSpellMask_DruidFaerieFireFeral
 	ClassSpellMask_DruidFerociousBite
 	ClassSpellMask_DruidFrenziedRegeneration
+	ClassSpellMask_DruidHealingTouch
 	ClassSpellMask_DruidHurricane
 	ClassSpellMask_DruidInsectSwarm
 	ClassSpellMask_DruidLacerate
+	ClassSpellMask_DruidLifebloom
 	ClassSpellMask_DruidMangleBear
 	ClassSpellMask_DruidMangleCat
 	ClassSpellMask_DruidMaul
 	ClassSpellMask_DruidMoonfire
 	ClassSpellMask_DruidRake
+	ClassSpellMask_DruidRegrowth
+	ClassSpellMask_DruidRejuvenation
 	ClassSpellMask_DruidRip
 	ClassSpellMask_DruidSavageRoar
 	ClassSpellMask_DruidShred
@@ -47,6 +51,7 @@ const (
 	ClassSpellMask_DruidSwipeCat
 	ClassSpellMask_DruidSwipeBear
 	ClassSpellMask_DruidTigersFury
+	ClassSpellMask_DruidWildGrowth
 	ClassSpellMask_DruidWrath
 
 	ClassSpellMask_DruidCatForm
@@ -61,6 +66,9 @@ const (
 
 	ClassSpellMask_DruidHarmfulGCDSpells = ClassSpellMask_DruidWrath | ClassSpellMask_DruidStarfire | ClassSpellMask_DruidMoonfire | ClassSpellMask_DruidInsectSwarm |
 		ClassSpellMask_DruidHurricane | ClassSpellMask_DruidStarsurge | ClassSpellMask_DruidSunfire | ClassSpellMask_DruidStarfall
+
+	ClassSpellMask_DruidHealingSpells = ClassSpellMask_DruidHealingTouch | ClassSpellMask_DruidLifebloom | ClassSpellMask_DruidRegrowth |
+		ClassSpellMask_DruidRejuvenation | ClassSpellMask_DruidWildGrowth
 )
 
 type Druid struct {
@@ -85,12 +93,14 @@ type Druid struct {
 	ForceOfNature        *DruidSpell
 	FrenziedRegeneration *DruidSpell
 	GiftOfTheWild        *DruidSpell
+	HealingTouch         []*DruidSpell
 	Hurricane            []*DruidSpell
 	Innervate            *DruidSpell
 	InsectSwarm          []*DruidSpell
 	Lacerate             *DruidSpell
 	LacerateBleed        *DruidSpell
 	Languish             *DruidSpell
+	Lifebloom            *DruidSpell
 	MangleBear           *DruidSpell
 	MangleCat            *DruidSpell
 	Berserk              *DruidSpell
@@ -98,6 +108,8 @@ type Druid struct {
 	Moonfire             []*DruidSpell
 	Rebirth              *DruidSpell
 	Rake                 *DruidSpell
+	Regrowth             []*DruidSpell
+	Rejuvenation         []*DruidSpell
 	Rip                  *DruidSpell
 	SavageRoar           *DruidSpell
 	Shred                *DruidSpell
@@ -113,6 +125,7 @@ type Druid struct {
 	SwipeCat             *DruidSpell
 	TigersFury           *DruidSpell
 	Typhoon              *DruidSpell
+	WildGrowth           *DruidSpell
 	curQueuedAutoSpell   *DruidSpell
 	MaulQueue            *DruidSpell
 	Wrath                []*DruidSpell
@@ -195,8 +208,38 @@ func (druid *Druid) AddRaidBuffs(raidBuffs *proto.RaidBuffs) {
 	}
 }
 
+func (druid *Druid) RosterBuffs() []core.RosterBuff {
+	buffs := []core.RosterBuff{
+		{
+			Name:      "Gift of the Wild",
+			Scope:     proto.RosterEffectScope_RosterEffectScopeRaid,
+			RaidBuffs: &proto.RaidBuffs{GiftOfTheWild: core.MakeTristateValue(true, druid.Talents.ImprovedMarkOfTheWild == 5)},
+		},
+		{
+			Name:    "Faerie Fire",
+			Scope:   proto.RosterEffectScope_RosterEffectScopeDebuff,
+			Debuffs: &proto.Debuffs{FaerieFire: true},
+		},
+	}
+	if druid.Talents.MoonkinForm {
+		buffs = append(buffs, core.RosterBuff{
+			Name:      "Moonkin Aura",
+			Scope:     proto.RosterEffectScope_RosterEffectScopeParty,
+			RaidBuffs: &proto.RaidBuffs{MoonkinAura: true},
+		})
+	}
+	if druid.Talents.LeaderOfThePack {
+		buffs = append(buffs, core.RosterBuff{
+			Name:      "Leader of the Pack",
+			Scope:     proto.RosterEffectScope_RosterEffectScopeParty,
+			RaidBuffs: &proto.RaidBuffs{LeaderOfThePack: true},
+		})
+	}
+	return buffs
+}
+
 func (druid *Druid) TryMaul(sim *core.Simulation, mhSwingSpell *core.Spell) *core.Spell {
-	if !druid.curQueueAura.IsActive() {
+	if !druid.curQueueAura.IsActive() || !druid.HasQueueLanded(sim, druid.curQueueAura.StartedAt()) {
 		return mhSwingSpell
 	}
 
@@ -256,6 +299,12 @@ func (druid *Druid) RegisterBalanceSpells() {
 	druid.registerWrathSpell()
 }
 
+func (druid *Druid) RegisterRestorationSpells() {
+	druid.registerHealingTouchSpell()
+	druid.registerRegrowthSpell()
+	druid.registerRejuvenationSpell()
+}
+
 // TODO: Classic feral
 func (druid *Druid) RegisterFeralCatSpells() {
 	druid.registerCatFormSpell()
@@ -348,6 +397,11 @@ func (druid *Druid) baseRuneAbilityDamage() float64 {
 	return 9.183105 + 0.616405*float64(druid.Level) + 0.028608*float64(druid.Level*druid.Level)
 }
 
+// Gift of Nature increases all healing done by 2% per rank.
+func (druid *Druid) healingMultiplier() float64 {
+	return 1 + 0.02*float64(druid.Talents.GiftOfNature)
+}
+
 // Agent is a generic way to access underlying druid on any of the agents (for example balance druid.)
 type DruidAgent interface {
 	GetDruid() *Druid
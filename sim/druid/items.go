@@ -47,6 +47,9 @@ const (
 func init() {
 	core.AddEffectsToTest = false
 
+	// Checked directly by the shapeshift forms and Lacerate.
+	core.NewImplicitItemEffects(WolfsheadHelm, IdolOfCruelty)
+
 	// https://www.wowhead.com/classic/item=236401/atiesh-greatstaff-of-the-guardian
 	core.NewItemEffect(AtieshDruid, func(agent core.Agent) {
 		character := agent.GetCharacter()
@@ -0,0 +1,92 @@
+package druid
+
+import (
+	"time"
+
+	"github.com/wowsims/sod/sim/core"
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+const LifebloomMaxStacks = 3
+
+// Lifebloom stacks up to 3 times on a target and blooms for a direct heal per stack when it expires.
+func (druid *Druid) registerLifebloomSpell() {
+	if !druid.HasRune(proto.DruidRune_RuneLegsLifebloom) {
+		return
+	}
+
+	actionID := core.ActionID{SpellID: int32(proto.DruidRune_RuneLegsLifebloom)}
+	baseTickHealing := druid.baseRuneAbilityDamage() * 0.26
+	baseBloomHealing := druid.baseRuneAbilityDamage() * 4.0
+
+	// Stack counts are kept here because the aura's own stacks are already cleared when OnExpire runs.
+	stacks := make(map[int32]int32)
+
+	bloomSpell := druid.RegisterSpell(Any, core.SpellConfig{
+		ActionID:       actionID.WithTag(1),
+		ClassSpellMask: ClassSpellMask_DruidLifebloom,
+		SpellSchool:    core.SpellSchoolNature,
+		DefenseType:    core.DefenseTypeMagic,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagPassiveSpell,
+
+		DamageMultiplier: druid.healingMultiplier(),
+		ThreatMultiplier: 1,
+		BonusCoefficient: 0.343,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			spell.CalcAndDealHealing(sim, target, baseBloomHealing*float64(stacks[target.UnitIndex]), spell.OutcomeHealingCrit)
+		},
+	})
+
+	druid.Lifebloom = druid.RegisterSpell(Humanoid|Tree, core.SpellConfig{
+		ActionID:       actionID,
+		ClassSpellMask: ClassSpellMask_DruidLifebloom,
+		SpellSchool:    core.SpellSchoolNature,
+		DefenseType:    core.DefenseTypeMagic,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          SpellFlagOmen | core.SpellFlagHelpful | core.SpellFlagAPL,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCost: 0.10,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+		},
+
+		Hot: core.DotConfig{
+			Aura: core.Aura{
+				Label:     "Lifebloom",
+				MaxStacks: LifebloomMaxStacks,
+				OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+					bloomSpell.Cast(sim, aura.Unit)
+					stacks[aura.Unit.UnitIndex] = 0
+				},
+			},
+			NumberOfTicks:    7,
+			TickLength:       time.Second,
+			BonusCoefficient: 0.074,
+			OnSnapshot: func(sim *core.Simulation, target *core.Unit, dot *core.Dot, isRollover bool) {
+				dot.Snapshot(target, baseTickHealing, isRollover)
+				dot.SnapshotBaseDamage *= float64(stacks[target.UnitIndex])
+			},
+			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
+				dot.CalcAndDealPeriodicSnapshotHealing(sim, target, dot.OutcomeTick)
+			},
+		},
+
+		DamageMultiplier: druid.healingMultiplier(),
+		ThreatMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			target = druid.HealTarget(sim, target)
+			hot := spell.Hot(target)
+
+			stacks[target.UnitIndex] = min(stacks[target.UnitIndex]+1, LifebloomMaxStacks)
+			hot.ApplyOrRefresh(sim)
+			hot.Aura.SetStacks(sim, stacks[target.UnitIndex])
+		},
+	})
+}
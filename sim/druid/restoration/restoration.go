@@ -28,14 +28,21 @@ func NewRestorationDruid(character *core.Character, options *proto.Player) *Rest
 	selfBuffs := druid.SelfBuffs{}
 
 	resto := &RestorationDruid{
-		Druid: druid.New(character, druid.Tree, selfBuffs, options.TalentsString),
+		Druid: druid.New(character, druid.Humanoid, selfBuffs, options.TalentsString),
 	}
 
-	resto.SelfBuffs.InnervateTarget = &proto.UnitReference{}
-	if restoOptions.Options.InnervateTarget != nil {
-		resto.SelfBuffs.InnervateTarget = restoOptions.Options.InnervateTarget
+	if innervateTarget := restoOptions.GetOptions().GetInnervateTarget(); innervateTarget.GetType() != proto.UnitReference_Unknown {
+		resto.SelfBuffs.InnervateTarget = innervateTarget
+	} else {
+		resto.SelfBuffs.InnervateTarget = &proto.UnitReference{
+			Type: proto.UnitReference_Self,
+		}
 	}
 
+	resto.EnableAutoAttacks(resto, core.AutoAttackOptions{
+		MainHand: resto.WeaponFromMainHand(),
+	})
+
 	return resto
 }
 
@@ -49,6 +56,7 @@ func (resto *RestorationDruid) GetDruid() *druid.Druid {
 
 func (resto *RestorationDruid) Initialize() {
 	resto.Druid.Initialize()
+	resto.RegisterRestorationSpells()
 }
 
 func (resto *RestorationDruid) Reset(sim *core.Simulation) {
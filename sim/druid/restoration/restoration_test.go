@@ -0,0 +1,79 @@
+package restoration
+
+import (
+	"testing"
+
+	_ "github.com/wowsims/sod/sim/common" // imported to get caster sets included.
+	"github.com/wowsims/sod/sim/core"
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+func init() {
+	RegisterRestorationDruid()
+}
+
+func TestRestoration(t *testing.T) {
+	core.RunTestSuite(t, t.Name(), core.FullCharacterTestSuiteGenerator([]core.CharacterSuiteConfig{
+		{
+			Class:      proto.Class_ClassDruid,
+			Phase:      4,
+			Level:      60,
+			Race:       proto.Race_RaceTauren,
+			OtherRaces: []proto.Race{proto.Race_RaceNightElf},
+			IsHealer:   true,
+
+			Talents:     Phase4Talents,
+			GearSet:     core.GetGearSet("../../../ui/restoration_druid/gear_sets", "phase_4"),
+			Rotation:    core.GetAplRotation("../../../ui/restoration_druid/apls", "phase_4"),
+			Buffs:       core.FullBuffsPhase4,
+			Consumes:    Phase4Consumes,
+			SpecOptions: core.SpecOptionsCombo{Label: "Default", SpecOptions: PlayerOptionsStandard},
+
+			ItemFilter:      ItemFilters,
+			EPReferenceStat: proto.Stat_StatHealingPower,
+			StatsToWeigh:    Stats,
+		},
+	}))
+}
+
+var Phase4Talents = "500050001--055503142315051"
+
+var Phase4Consumes = core.ConsumesCombo{
+	Label: "P4-Consumes",
+	Consumes: &proto.Consumes{
+		DefaultPotion: proto.Potions_MajorManaPotion,
+		Flask:         proto.Flask_FlaskOfDistilledWisdom,
+		Food:          proto.Food_FoodNightfinSoup,
+		MainHandImbue: proto.WeaponImbue_BrilliantManaOil,
+	},
+}
+
+var PlayerOptionsStandard = &proto.Player_RestorationDruid{
+	RestorationDruid: &proto.RestorationDruid{
+		Options: &proto.RestorationDruid_Options{
+			InnervateTarget: &proto.UnitReference{Type: proto.UnitReference_Self},
+		},
+	},
+}
+
+var ItemFilters = core.ItemFilter{
+	WeaponTypes: []proto.WeaponType{
+		proto.WeaponType_WeaponTypeDagger,
+		proto.WeaponType_WeaponTypeMace,
+		proto.WeaponType_WeaponTypeOffHand,
+		proto.WeaponType_WeaponTypeStaff,
+		proto.WeaponType_WeaponTypePolearm,
+	},
+	ArmorType: proto.ArmorType_ArmorTypeLeather,
+	RangedWeaponTypes: []proto.RangedWeaponType{
+		proto.RangedWeaponType_RangedWeaponTypeIdol,
+	},
+}
+
+var Stats = []proto.Stat{
+	proto.Stat_StatIntellect,
+	proto.Stat_StatSpirit,
+	proto.Stat_StatHealingPower,
+	proto.Stat_StatSpellCrit,
+	proto.Stat_StatMP5,
+}
@@ -29,6 +29,7 @@ func (druid *Druid) ApplyRunes() {
 	// Hands
 	druid.registerSunfireSpell()
 	druid.applyMangle()
+	druid.registerWildGrowthSpell()
 
 	// Belt
 	druid.applyBerserk()
@@ -39,6 +40,7 @@ func (druid *Druid) ApplyRunes() {
 	druid.applySavageRoar()
 	druid.registerLacerateBleedSpell()
 	druid.registerLacerateSpell()
+	druid.registerLifebloomSpell()
 
 	// Feet
 	druid.applyDreamstate()
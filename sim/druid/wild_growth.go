@@ -0,0 +1,82 @@
+package druid
+
+import (
+	"time"
+
+	"github.com/wowsims/sod/sim/core"
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+const WildGrowthTargetCount = 5
+
+func (druid *Druid) registerWildGrowthSpell() {
+	if !druid.HasRune(proto.DruidRune_RuneHandsWildGrowth) {
+		return
+	}
+
+	ticks := int32(7)
+	baseTickHealing := druid.baseRuneAbilityDamage() * 2.7 / float64(ticks)
+
+	druid.WildGrowth = druid.RegisterSpell(Humanoid|Tree, core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: int32(proto.DruidRune_RuneHandsWildGrowth)},
+		ClassSpellMask: ClassSpellMask_DruidWildGrowth,
+		SpellSchool:    core.SpellSchoolNature,
+		DefenseType:    core.DefenseTypeMagic,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          SpellFlagOmen | core.SpellFlagHelpful | core.SpellFlagAPL,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCost: 0.23,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+			CD: core.Cooldown{
+				Timer:    druid.NewTimer(),
+				Duration: time.Second * 6,
+			},
+		},
+
+		Hot: core.DotConfig{
+			Aura: core.Aura{
+				Label: "Wild Growth",
+			},
+			NumberOfTicks:    ticks,
+			TickLength:       time.Second,
+			BonusCoefficient: 0.8 / float64(ticks),
+			OnSnapshot: func(sim *core.Simulation, target *core.Unit, dot *core.Dot, isRollover bool) {
+				dot.Snapshot(target, baseTickHealing, isRollover)
+			},
+			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
+				// Front-loaded: the first tick heals for 30% more than average and each later tick 10% less.
+				result := dot.CalcSnapshotHealing(sim, target, dot.OutcomeTick)
+				result.Damage *= 1 + 0.1*float64(3-(dot.TickCount-1))
+				dot.Spell.DealPeriodicHealing(sim, result)
+			},
+		},
+
+		DamageMultiplier: druid.healingMultiplier(),
+		ThreatMultiplier: 1,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			for _, unit := range druid.wildGrowthTargets(druid.HealTarget(sim, target)) {
+				spell.Hot(unit).Apply(sim)
+			}
+		},
+	})
+}
+
+// The chosen target is always healed, followed by the rest of the druid's party.
+func (druid *Druid) wildGrowthTargets(primary *core.Unit) []*core.Unit {
+	targets := []*core.Unit{primary}
+	for _, agent := range druid.Party.PlayersAndPets {
+		if len(targets) == WildGrowthTargetCount {
+			break
+		}
+		if unit := &agent.GetCharacter().Unit; unit != primary {
+			targets = append(targets, unit)
+		}
+	}
+	return targets
+}
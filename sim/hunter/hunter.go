@@ -176,6 +176,24 @@ func (hunter *Hunter) AddRaidBuffs(raidBuffs *proto.RaidBuffs) {
 func (hunter *Hunter) AddPartyBuffs(_ *proto.PartyBuffs) {
 }
 
+func (hunter *Hunter) RosterBuffs() []core.RosterBuff {
+	buffs := []core.RosterBuff{
+		{
+			Name:    "Hunter's Mark",
+			Scope:   proto.RosterEffectScope_RosterEffectScopeDebuff,
+			Debuffs: &proto.Debuffs{HuntersMark: core.MakeTristateValue(true, hunter.Talents.ImprovedHuntersMark == 5)},
+		},
+	}
+	if hunter.Talents.TrueshotAura {
+		buffs = append(buffs, core.RosterBuff{
+			Name:      "Trueshot Aura",
+			Scope:     proto.RosterEffectScope_RosterEffectScopeParty,
+			RaidBuffs: &proto.RaidBuffs{TrueshotAura: true},
+		})
+	}
+	return buffs
+}
+
 func (hunter *Hunter) Initialize() {
 	hunter.OnSpellRegistered(func(spell *core.Spell) {
 		if spell.Matches(ClassSpellMask_HunterShots) {
@@ -17,7 +17,7 @@ var RaptorStrikeLevel = [RaptorStrikeRanks + 1]int{0, 1, 8, 16, 24, 32, 40, 48,
 
 // Returns true if the regular melee swing should be used, false otherwise.
 func (hunter *Hunter) TryRaptorStrike(sim *core.Simulation, mhSwingSpell *core.Spell) *core.Spell {
-	if hunter.curQueuedAutoSpell != nil && hunter.curQueuedAutoSpell.CanCast(sim, hunter.CurrentTarget) {
+	if hunter.curQueuedAutoSpell != nil && hunter.HasQueueLanded(sim, hunter.curQueueAura.StartedAt()) && hunter.curQueuedAutoSpell.CanCast(sim, hunter.CurrentTarget) {
 		return hunter.curQueuedAutoSpell
 	}
 	return mhSwingSpell
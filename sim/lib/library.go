@@ -3,16 +3,15 @@ package main
 // #include <stdlib.h>
 import "C"
 import (
-	"bytes"
-	"compress/zlib"
-	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"log"
 	"unsafe"
 
 	"github.com/wowsims/sod/sim"
 	"github.com/wowsims/sod/sim/core"
 	"github.com/wowsims/sod/sim/core/proto"
+	"github.com/wowsims/sod/sim/core/sharelink"
 	"github.com/wowsims/sod/sim/core/simsignals"
 	"google.golang.org/protobuf/encoding/protojson"
 	goproto "google.golang.org/protobuf/proto"
@@ -62,35 +61,35 @@ func computeStats(json *C.char) *C.char {
 	return C.CString(string(out))
 }
 
+// Encodes a request as the fragment of a share link. Requests with a single player give the
+// fragment of its individual sim link, any other request the fragment of a raid sim link.
+// Failures are returned as "error: <reason>" rather than ending the process that loaded the
+// library; base64 fragments never contain a colon or a space.
+//
 //export encodeSettings
 func encodeSettings(json *C.char) *C.char {
 	input := &proto.RaidSimRequest{}
 	jsonString := C.GoString(json)
-	err := protojson.Unmarshal([]byte(jsonString), input)
-	if err != nil {
-		log.Fatalf("failed to load input json file: %s", err)
+	if err := protojson.Unmarshal([]byte(jsonString), input); err != nil {
+		return C.CString("error: failed to load input json: " + err.Error())
 	}
-	settings := &proto.IndividualSimSettings{
-		Settings: &proto.SimSettings{
-			Iterations: input.SimOptions.Iterations,
-		},
-		RaidBuffs:  input.Raid.Buffs,
-		Debuffs:    input.Raid.Debuffs,
-		Tanks:      input.Raid.Tanks,
-		PartyBuffs: input.Raid.Parties[0].Buffs,
-		Player:     input.Raid.Parties[0].Players[0],
-		Encounter:  input.Encounter,
+
+	var settings goproto.Message
+	individual, err := sharelink.RequestToIndividualSettings(input)
+	switch {
+	case errors.Is(err, sharelink.ErrNotIndividual):
+		settings = sharelink.RequestToRaidSettings(input)
+	case err != nil:
+		return C.CString("error: " + err.Error())
+	default:
+		settings = individual
 	}
-	var buffer bytes.Buffer
-	data, err := goproto.Marshal(settings)
+
+	out, err := sharelink.EncodeSettings(settings)
 	if err != nil {
-		panic(err)
+		return C.CString("error: " + err.Error())
 	}
-	writer := zlib.NewWriter(&buffer)
-	writer.Write(data)
-	writer.Close()
-	out := base64.StdEncoding.EncodeToString(buffer.Bytes())
-	return C.CString(string(out))
+	return C.CString(out)
 }
 
 //export getDatabase
@@ -37,6 +37,9 @@ func (mage *Mage) newBlizzardSpellConfig(rank int) core.SpellConfig {
 
 	spellCoeff := .042
 
+	// Ground effect, centered on the target at the time of casting.
+	var center core.Vector2
+
 	return core.SpellConfig{
 		ClassSpellMask: ClassSpellMask_MageBlizzard,
 		ActionID:       core.ActionID{SpellID: spellId},
@@ -69,6 +72,9 @@ func (mage *Mage) newBlizzardSpellConfig(rank int) core.SpellConfig {
 			},
 			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
 				for _, aoeTarget := range sim.Encounter.TargetUnits {
+					if !aoeTarget.IsWithinRadius(center, 8) {
+						continue
+					}
 					dot.CalcAndDealPeriodicSnapshotDamage(sim, aoeTarget, dot.OutcomeTick)
 				}
 			},
@@ -78,6 +84,7 @@ func (mage *Mage) newBlizzardSpellConfig(rank int) core.SpellConfig {
 		ThreatMultiplier: 1,
 
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			center = target.Position
 			spell.AOEDot().Apply(sim)
 		},
 	}
@@ -6,7 +6,8 @@ import (
 	"github.com/wowsims/sod/sim/core"
 )
 
-// This exists purely so that it can be used to extend the arcane buff from the mage T1 4pc
+// Interrupts the target's cast, which only matters against players in a duel. It's also
+// used to extend the arcane buff from the mage T1 4pc.
 func (mage *Mage) registerCounterspellSpell() {
 	mage.RegisterSpell(core.SpellConfig{
 		ActionID:       core.ActionID{SpellID: 2139},
@@ -27,8 +28,13 @@ func (mage *Mage) registerCounterspellSpell() {
 		},
 
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
-			// Do nothing
 			// TODO: Generates a high amount of threat
+			if !target.CanBeCrowdControlled() || (!target.IsCasting(sim) && !target.IsChanneling(sim)) {
+				return
+			}
+			if result := spell.CalcAndDealOutcome(sim, target, spell.OutcomeMagicHit); result.Landed() {
+				target.InterruptCast(sim, time.Second*10)
+			}
 		},
 	})
 }
@@ -166,6 +166,31 @@ func (mage *Mage) AddRaidBuffs(raidBuffs *proto.RaidBuffs) {
 func (mage *Mage) AddPartyBuffs(partyBuffs *proto.PartyBuffs) {
 }
 
+func (mage *Mage) RosterBuffs() []core.RosterBuff {
+	buffs := []core.RosterBuff{
+		{
+			Name:      "Arcane Brilliance",
+			Scope:     proto.RosterEffectScope_RosterEffectScopeRaid,
+			RaidBuffs: &proto.RaidBuffs{ArcaneBrilliance: true},
+		},
+	}
+	if mage.Talents.ImprovedScorch == 3 {
+		buffs = append(buffs, core.RosterBuff{
+			Name:    "Improved Scorch",
+			Scope:   proto.RosterEffectScope_RosterEffectScopeDebuff,
+			Debuffs: &proto.Debuffs{ImprovedScorch: true},
+		})
+	}
+	if mage.Talents.WintersChill == 5 {
+		buffs = append(buffs, core.RosterBuff{
+			Name:    "Winter's Chill",
+			Scope:   proto.RosterEffectScope_RosterEffectScopeDebuff,
+			Debuffs: &proto.Debuffs{WintersChill: true},
+		})
+	}
+	return buffs
+}
+
 func (mage *Mage) Initialize() {
 	mage.registerArcaneMissilesSpell()
 	mage.registerFireballSpell()
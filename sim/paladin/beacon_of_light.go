@@ -0,0 +1,70 @@
+package paladin
+
+import (
+	"time"
+
+	"github.com/wowsims/sod/sim/core"
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+const beaconOfLightTransferRatio = 0.75
+
+func (paladin *Paladin) registerBeaconOfLight() {
+	if !paladin.hasRune(proto.PaladinRune_RuneHandsBeaconOfLight) {
+		return
+	}
+
+	actionID := core.ActionID{SpellID: int32(proto.PaladinRune_RuneHandsBeaconOfLight)}
+	var beaconTarget *core.Unit
+
+	beaconHeal := paladin.RegisterSpell(core.SpellConfig{
+		ActionID:    actionID.WithTag(1),
+		SpellSchool: core.SpellSchoolHoly,
+		DefenseType: core.DefenseTypeMagic,
+		ProcMask:    core.ProcMaskSpellHealing,
+		Flags:       core.SpellFlagHelpful | core.SpellFlagPassiveSpell | core.SpellFlagNoOnCastComplete,
+
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+	})
+
+	paladin.beaconOfLightAura = paladin.RegisterAura(core.Aura{
+		Label:    "Beacon of Light",
+		ActionID: actionID,
+		Duration: time.Minute,
+		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+			beaconTarget = nil
+		},
+		OnHealDealt: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+			if !spell.Matches(ClassSpellMask_PaladinHeals) || beaconTarget == nil || result.Target == beaconTarget {
+				return
+			}
+			// The transferred amount already includes the paladin's healing modifiers.
+			transfer := result.Damage * beaconOfLightTransferRatio / beaconHeal.CasterHealingMultiplier(false)
+			beaconHeal.CalcAndDealHealing(sim, beaconTarget, transfer, beaconHeal.OutcomeHealing)
+		},
+	})
+
+	paladin.RegisterSpell(core.SpellConfig{
+		ActionID:       actionID,
+		SpellSchool:    core.SpellSchoolHoly,
+		ProcMask:       core.ProcMaskEmpty,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagAPL,
+		ClassSpellMask: ClassSpellMask_PaladinBeaconOfLight,
+
+		ManaCost: core.ManaCostOptions{
+			BaseCost: 0.35,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+		},
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			paladin.beaconOfLightAura.Deactivate(sim)
+			beaconTarget = paladin.HealTarget(sim, target)
+			paladin.beaconOfLightAura.Activate(sim)
+		},
+	})
+}
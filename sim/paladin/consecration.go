@@ -43,6 +43,9 @@ func (paladin *Paladin) registerConsecration() {
 			break
 		}
 
+		// Consecration stays where the paladin stood when casting it.
+		var center core.Vector2
+
 		paladin.RegisterSpell(core.SpellConfig{
 			ActionID:    core.ActionID{SpellID: rank.spellID},
 			SpellSchool: core.SpellSchoolHoly,
@@ -89,6 +92,9 @@ func (paladin *Paladin) registerConsecration() {
 					// silent failure (missing damage tick).
 					outcomeApplier := core.Ternary(hasWrath, dot.OutcomeMagicHitAndSnapshotCrit, dot.Spell.OutcomeMagicHit)
 					for _, aoeTarget := range sim.Encounter.TargetUnits {
+						if !aoeTarget.IsWithinRadius(center, 8) {
+							continue
+						}
 						dot.CalcAndDealPeriodicSnapshotDamage(sim, aoeTarget, outcomeApplier)
 					}
 
@@ -106,6 +112,7 @@ func (paladin *Paladin) registerConsecration() {
 			},
 
 			ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+				center = paladin.Position
 				spell.AOEDot().Apply(sim)
 			},
 		})
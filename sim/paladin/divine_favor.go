@@ -13,7 +13,7 @@ func (paladin *Paladin) registerDivineFavor() {
 
 	var affectedSpells []*core.Spell
 	paladin.OnSpellRegistered(func(spell *core.Spell) {
-		if spell.Matches(ClassSpellMask_PaladinHolyShock) {
+		if spell.Matches(ClassSpellMask_PaladinHolyShock | ClassSpellMask_PaladinHeals) {
 			affectedSpells = append(affectedSpells, spell)
 		}
 	})
@@ -46,6 +46,14 @@ func (paladin *Paladin) registerDivineFavor() {
 			cd.Set(sim.CurrentTime + cd.Duration)
 			paladin.UpdateMajorCooldowns()
 		},
+		OnHealDealt: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+			if !spell.Matches(ClassSpellMask_PaladinHeals) {
+				return
+			}
+			aura.Deactivate(sim)
+			cd.Set(sim.CurrentTime + cd.Duration)
+			paladin.UpdateMajorCooldowns()
+		},
 	})
 
 	divineFavor := paladin.RegisterSpell(core.SpellConfig{
@@ -0,0 +1,63 @@
+package paladin
+
+import (
+	"time"
+
+	"github.com/wowsims/sod/sim/core"
+)
+
+func (paladin *Paladin) registerFlashOfLight() {
+	ranks := []struct {
+		level      int32
+		spellID    int32
+		manaCost   float64
+		minHealing float64
+		maxHealing float64
+	}{
+		{level: 20, spellID: 19750, manaCost: 35, minHealing: 67, maxHealing: 77},
+		{level: 26, spellID: 19939, manaCost: 50, minHealing: 102, maxHealing: 117},
+		{level: 34, spellID: 19940, manaCost: 70, minHealing: 153, maxHealing: 171},
+		{level: 42, spellID: 19941, manaCost: 90, minHealing: 206, maxHealing: 231},
+		{level: 50, spellID: 19942, manaCost: 115, minHealing: 278, maxHealing: 310},
+		{level: 58, spellID: 19943, manaCost: 140, minHealing: 348, maxHealing: 389},
+	}
+
+	for i, rank := range ranks {
+		rank := rank
+		if paladin.Level < rank.level {
+			break
+		}
+
+		paladin.flashOfLight = append(paladin.flashOfLight, paladin.RegisterSpell(core.SpellConfig{
+			ActionID:    core.ActionID{SpellID: rank.spellID},
+			SpellSchool: core.SpellSchoolHoly,
+			DefenseType: core.DefenseTypeMagic,
+			ProcMask:    core.ProcMaskSpellHealing,
+			Flags:       core.SpellFlagHelpful | core.SpellFlagAPL,
+
+			RequiredLevel: int(rank.level),
+			Rank:          i + 1,
+
+			ClassSpellMask: ClassSpellMask_PaladinFlashOfLight,
+
+			ManaCost: core.ManaCostOptions{
+				FlatCost: rank.manaCost,
+			},
+			Cast: core.CastConfig{
+				DefaultCast: core.Cast{
+					GCD:      core.GCDDefault,
+					CastTime: time.Millisecond * 1500,
+				},
+			},
+
+			DamageMultiplier: 1,
+			ThreatMultiplier: 1,
+			BonusCoefficient: 0.429,
+
+			ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+				baseHealing := sim.Roll(rank.minHealing, rank.maxHealing)
+				spell.CalcAndDealHealing(sim, paladin.HealTarget(sim, target), baseHealing, spell.OutcomeHealingCrit)
+			},
+		}))
+	}
+}
@@ -0,0 +1,54 @@
+package holy
+
+import (
+	"github.com/wowsims/sod/sim/core"
+	"github.com/wowsims/sod/sim/core/proto"
+	"github.com/wowsims/sod/sim/paladin"
+)
+
+func RegisterHolyPaladin() {
+	core.RegisterAgentFactory(
+		proto.Player_HolyPaladin{},
+		proto.Spec_SpecHolyPaladin,
+		func(character *core.Character, options *proto.Player) core.Agent {
+			return NewHolyPaladin(character, options)
+		},
+		func(player *proto.Player, spec interface{}) {
+			playerSpec, ok := spec.(*proto.Player_HolyPaladin)
+			if !ok {
+				panic("Invalid spec value for Holy Paladin!")
+			}
+			player.Spec = playerSpec
+		},
+	)
+}
+
+func NewHolyPaladin(character *core.Character, options *proto.Player) *HolyPaladin {
+	holyOptions := options.GetHolyPaladin().Options
+
+	holy := &HolyPaladin{
+		Paladin: paladin.NewPaladin(character, options, holyOptions),
+	}
+
+	holy.EnableAutoAttacks(holy, core.AutoAttackOptions{
+		MainHand: holy.WeaponFromMainHand(),
+	})
+
+	return holy
+}
+
+type HolyPaladin struct {
+	*paladin.Paladin
+}
+
+func (holy *HolyPaladin) GetPaladin() *paladin.Paladin {
+	return holy.Paladin
+}
+
+func (holy *HolyPaladin) Initialize() {
+	holy.Paladin.Initialize()
+}
+
+func (holy *HolyPaladin) Reset(sim *core.Simulation) {
+	holy.Paladin.Reset(sim)
+}
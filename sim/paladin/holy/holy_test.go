@@ -0,0 +1,78 @@
+package holy
+
+import (
+	"testing"
+
+	_ "github.com/wowsims/sod/sim/common" // imported to get caster sets included.
+	"github.com/wowsims/sod/sim/core"
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+func init() {
+	RegisterHolyPaladin()
+}
+
+func TestHoly(t *testing.T) {
+	core.RunTestSuite(t, t.Name(), core.FullCharacterTestSuiteGenerator([]core.CharacterSuiteConfig{
+		{
+			Class:      proto.Class_ClassPaladin,
+			Phase:      5,
+			Level:      60,
+			Race:       proto.Race_RaceHuman,
+			OtherRaces: []proto.Race{proto.Race_RaceDwarf},
+			IsHealer:   true,
+
+			Talents:     Phase5Talents,
+			GearSet:     core.GetGearSet("../../../ui/holy_paladin/gear_sets", "phase_5"),
+			Rotation:    core.GetAplRotation("../../../ui/holy_paladin/apls", "phase_5"),
+			Buffs:       core.FullBuffsPhase5,
+			Consumes:    Phase5Consumes,
+			SpecOptions: core.SpecOptionsCombo{Label: "Default", SpecOptions: PlayerOptionsDefault},
+
+			ItemFilter:      ItemFilters,
+			EPReferenceStat: proto.Stat_StatHealingPower,
+			StatsToWeigh:    Stats,
+		},
+	}))
+}
+
+var Phase5Talents = "05503101521351"
+
+var Phase5Consumes = core.ConsumesCombo{
+	Label: "P5-Consumes",
+	Consumes: &proto.Consumes{
+		DefaultPotion: proto.Potions_MajorManaPotion,
+		Flask:         proto.Flask_FlaskOfDistilledWisdom,
+		Food:          proto.Food_FoodNightfinSoup,
+		MainHandImbue: proto.WeaponImbue_BrilliantManaOil,
+	},
+}
+
+var PlayerOptionsDefault = &proto.Player_HolyPaladin{
+	HolyPaladin: &proto.HolyPaladin{
+		Options: &proto.PaladinOptions{
+			Aura: proto.PaladinAura_ConcentrationAura,
+		},
+	},
+}
+
+var ItemFilters = core.ItemFilter{
+	WeaponTypes: []proto.WeaponType{
+		proto.WeaponType_WeaponTypeMace,
+		proto.WeaponType_WeaponTypeOffHand,
+		proto.WeaponType_WeaponTypeShield,
+		proto.WeaponType_WeaponTypeSword,
+	},
+	ArmorType: proto.ArmorType_ArmorTypePlate,
+	RangedWeaponTypes: []proto.RangedWeaponType{
+		proto.RangedWeaponType_RangedWeaponTypeLibram,
+	},
+}
+
+var Stats = []proto.Stat{
+	proto.Stat_StatIntellect,
+	proto.Stat_StatSpirit,
+	proto.Stat_StatHealingPower,
+	proto.Stat_StatSpellCrit,
+	proto.Stat_StatMP5,
+}
@@ -0,0 +1,66 @@
+package paladin
+
+import (
+	"time"
+
+	"github.com/wowsims/sod/sim/core"
+)
+
+func (paladin *Paladin) registerHolyLight() {
+	ranks := []struct {
+		level      int32
+		spellID    int32
+		manaCost   float64
+		minHealing float64
+		maxHealing float64
+	}{
+		{level: 1, spellID: 635, manaCost: 35, minHealing: 42, maxHealing: 51},
+		{level: 6, spellID: 639, manaCost: 60, minHealing: 81, maxHealing: 96},
+		{level: 14, spellID: 647, manaCost: 110, minHealing: 167, maxHealing: 196},
+		{level: 22, spellID: 1026, manaCost: 190, minHealing: 322, maxHealing: 368},
+		{level: 30, spellID: 1042, manaCost: 275, minHealing: 506, maxHealing: 569},
+		{level: 38, spellID: 1043, manaCost: 365, minHealing: 717, maxHealing: 799},
+		{level: 46, spellID: 1044, manaCost: 465, minHealing: 968, maxHealing: 1076},
+		{level: 54, spellID: 10328, manaCost: 580, minHealing: 1272, maxHealing: 1414},
+		{level: 60, spellID: 10329, manaCost: 660, minHealing: 1619, maxHealing: 1799},
+	}
+
+	for i, rank := range ranks {
+		rank := rank
+		if paladin.Level < rank.level {
+			break
+		}
+
+		paladin.holyLight = append(paladin.holyLight, paladin.RegisterSpell(core.SpellConfig{
+			ActionID:    core.ActionID{SpellID: rank.spellID},
+			SpellSchool: core.SpellSchoolHoly,
+			DefenseType: core.DefenseTypeMagic,
+			ProcMask:    core.ProcMaskSpellHealing,
+			Flags:       core.SpellFlagHelpful | core.SpellFlagAPL,
+
+			RequiredLevel: int(rank.level),
+			Rank:          i + 1,
+
+			ClassSpellMask: ClassSpellMask_PaladinHolyLight,
+
+			ManaCost: core.ManaCostOptions{
+				FlatCost: rank.manaCost,
+			},
+			Cast: core.CastConfig{
+				DefaultCast: core.Cast{
+					GCD:      core.GCDDefault,
+					CastTime: time.Millisecond * 2500,
+				},
+			},
+
+			DamageMultiplier: 1,
+			ThreatMultiplier: 1,
+			BonusCoefficient: 0.714,
+
+			ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+				baseHealing := sim.Roll(rank.minHealing, rank.maxHealing)
+				spell.CalcAndDealHealing(sim, paladin.HealTarget(sim, target), baseHealing, spell.OutcomeHealingCrit)
+			},
+		}))
+	}
+}
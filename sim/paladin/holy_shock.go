@@ -28,15 +28,16 @@ func (paladin *Paladin) registerHolyShock() {
 	}
 
 	ranks := []struct {
-		level     int32
-		spellID   int32
-		manaCost  float64
-		minDamage float64
-		maxDamage float64
+		level       int32
+		spellID     int32
+		healSpellID int32
+		manaCost    float64
+		minDamage   float64
+		maxDamage   float64
 	}{
-		{level: 40, spellID: 20473, manaCost: 225, minDamage: 204, maxDamage: 220},
-		{level: 48, spellID: 20929, manaCost: 275, minDamage: 279, maxDamage: 301},
-		{level: 56, spellID: 20930, manaCost: 325, minDamage: 365, maxDamage: 395},
+		{level: 40, spellID: 20473, healSpellID: 25914, manaCost: 225, minDamage: 204, maxDamage: 220},
+		{level: 48, spellID: 20929, healSpellID: 25913, manaCost: 275, minDamage: 279, maxDamage: 301},
+		{level: 56, spellID: 20930, healSpellID: 25903, manaCost: 325, minDamage: 365, maxDamage: 395},
 	}
 
 	//hasArtOfWar := paladin.hasRune(proto.PaladinRune_RuneFeetTheArtOfWar)
@@ -97,5 +98,45 @@ func (paladin *Paladin) registerHolyShock() {
 				}
 			},
 		})
+
+		// The healing half of Holy Shock, sharing its cooldown.
+		paladin.holyShockHeal = append(paladin.holyShockHeal, paladin.RegisterSpell(core.SpellConfig{
+			ActionID:    core.ActionID{SpellID: rank.healSpellID},
+			SpellSchool: core.SpellSchoolHoly,
+			DefenseType: core.DefenseTypeMagic,
+			ProcMask:    core.ProcMaskSpellHealing,
+			Flags:       core.SpellFlagHelpful | core.SpellFlagAPL,
+
+			RequiredLevel: int(rank.level),
+			Rank:          i + 1,
+
+			ClassSpellMask: ClassSpellMask_PaladinHolyShockHeal,
+
+			ManaCost: core.ManaCostOptions{
+				FlatCost:   rank.manaCost,
+				Multiplier: manaCostMultiplier,
+			},
+
+			Cast: core.CastConfig{
+				DefaultCast: core.Cast{
+					GCD: core.GCDDefault,
+				},
+				CD: *paladin.holyShockCooldown,
+			},
+
+			DamageMultiplier: 1,
+			ThreatMultiplier: 1,
+			BonusCoefficient: 0.429,
+
+			ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+				baseHealing := sim.Roll(rank.minDamage, rank.maxDamage)
+				result := spell.CalcAndDealHealing(sim, paladin.HealTarget(sim, target), baseHealing, spell.OutcomeHealingCrit)
+
+				if hasInfusionOfLight && result.Outcome.Matches(core.OutcomeCrit) {
+					paladin.AddMana(sim, rank.manaCost, manaMetrics)
+					paladin.holyShockCooldown.Set(sim.CurrentTime + max(0, paladin.holyShockCooldown.TimeToReady(sim)-(time.Second*3)))
+				}
+			},
+		}))
 	}
 }
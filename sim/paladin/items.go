@@ -42,6 +42,9 @@ const (
 func init() {
 	core.AddEffectsToTest = false
 
+	// Librams checked directly by the spells they modify.
+	core.NewImplicitItemEffects(LibramOfHope, LibramOfFervor, LibramOfBenediction, LibramOfAvenging)
+
 	core.NewItemEffect(BandOfRedemption, func(agent core.Agent) {
 		character := agent.GetCharacter()
 		triggerAura := core.MakeProcTriggerAura(&character.Unit, core.ProcTrigger{
@@ -48,6 +48,9 @@ const (
 
 	ClassSpellMask_PaladinHolyLight
 	ClassSpellMask_PaladinSunlight
+	ClassSpellMask_PaladinFlashOfLight
+	ClassSpellMask_PaladinHolyShockHeal
+	ClassSpellMask_PaladinBeaconOfLight
 
 	ClassSpellMask_PaladinAll = 1<<iota - 1
 
@@ -60,6 +63,9 @@ const (
 
 	ClassSpellMask_PaladinSeals = ClassSpellMask_PaladinSealOfCommand | ClassSpellMask_PaladinSealOfMartyrdom |
 		ClassSpellMask_PaladinSealOfRighteousness
+
+	// Direct heals, affected by Divine Favor and Illumination and transferred to the Beacon of Light target.
+	ClassSpellMask_PaladinHeals = ClassSpellMask_PaladinHolyLight | ClassSpellMask_PaladinFlashOfLight | ClassSpellMask_PaladinHolyShockHeal
 )
 
 type OnHolyPowerSpent func(sim *core.Simulation, holyPower int32)
@@ -107,6 +113,10 @@ type Paladin struct {
 	redoubtAura           *core.Aura
 	righteousFuryAura     *core.Aura
 	holyWrath             []*core.Spell
+	holyLight             []*core.Spell
+	flashOfLight          []*core.Spell
+	holyShockHeal         []*core.Spell
+	beaconOfLightAura     *core.Aura
 	divineProtection      *core.Spell
 	auraMasteryAura       *core.Aura
 	shockAndAweAura       *core.Aura
@@ -156,6 +166,35 @@ func (paladin *Paladin) AddRaidBuffs(_ *proto.RaidBuffs) {
 func (paladin *Paladin) AddPartyBuffs(_ *proto.PartyBuffs) {
 }
 
+func (paladin *Paladin) RosterBuffs() []core.RosterBuff {
+	var raidBuffs *proto.RaidBuffs
+	switch paladin.Options.Aura {
+	case proto.PaladinAura_DevotionAura:
+		raidBuffs = &proto.RaidBuffs{DevotionAura: core.MakeTristateValue(true, paladin.Talents.ImprovedDevotionAura == 5)}
+	case proto.PaladinAura_RetributionAura:
+		raidBuffs = &proto.RaidBuffs{RetributionAura: core.MakeTristateValue(true, paladin.Talents.ImprovedRetributionAura == 2)}
+	case proto.PaladinAura_SanctityAura:
+		if paladin.Talents.SanctityAura {
+			raidBuffs = &proto.RaidBuffs{SanctityAura: true}
+		}
+	case proto.PaladinAura_FrostResistanceAura:
+		raidBuffs = &proto.RaidBuffs{FrostResistanceAura: true}
+	case proto.PaladinAura_ShadowResistanceAura:
+		raidBuffs = &proto.RaidBuffs{ShadowResistanceAura: true}
+	case proto.PaladinAura_FireResistanceAura:
+		raidBuffs = &proto.RaidBuffs{FireResistanceAura: true}
+	}
+	if raidBuffs == nil {
+		return nil
+	}
+
+	return []core.RosterBuff{{
+		Name:      paladin.Options.Aura.String(),
+		Scope:     proto.RosterEffectScope_RosterEffectScopeParty,
+		RaidBuffs: raidBuffs,
+	}}
+}
+
 func (paladin *Paladin) shouldAttachStopAttack(spell *core.Spell) bool {
 	return (paladin.Options.IsUsingJudgementStopAttack && spell.Matches(ClassSpellMask_PaladinJudgements)) ||
 		(paladin.Options.IsUsingExorcismStopAttack && spell.Matches(ClassSpellMask_PaladinExorcism)) ||
@@ -240,6 +279,9 @@ func (paladin *Paladin) Initialize() {
 	paladin.registerShieldOfRighteousness()
 	paladin.registerBlessingOfSanctuary()
 	paladin.registerLayOnHands()
+	paladin.registerHolyLight()
+	paladin.registerFlashOfLight()
+	paladin.registerBeaconOfLight()
 
 	paladin.enableMultiJudge = false // Was previously true in Phase 4 but disabled in Phase 5
 	paladin.lingerDuration = time.Millisecond * 400
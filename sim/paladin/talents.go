@@ -44,6 +44,7 @@ func (paladin *Paladin) ApplyTalents() {
 	paladin.applyImprovedLayOnHands()
 
 	paladin.applyHealingLight()
+	paladin.applyIllumination()
 }
 
 func (paladin *Paladin) improvedSoR() float64 {
@@ -246,3 +247,24 @@ func (paladin *Paladin) applyHealingLight() {
 		})
 	}
 }
+
+func (paladin *Paladin) applyIllumination() {
+	if paladin.Talents.Illumination == 0 {
+		return
+	}
+
+	procChance := 0.2 * float64(paladin.Talents.Illumination)
+	manaMetrics := paladin.NewManaMetrics(core.ActionID{SpellID: 20237})
+
+	core.MakePermanent(paladin.RegisterAura(core.Aura{
+		Label: "Illumination",
+		OnHealDealt: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+			if !spell.Matches(ClassSpellMask_PaladinHeals) || !result.DidCrit() {
+				return
+			}
+			if sim.Proc(procChance, "Illumination") {
+				paladin.AddMana(sim, spell.Cost.BaseCost, manaMetrics)
+			}
+		},
+	}))
+}
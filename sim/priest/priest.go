@@ -132,6 +132,36 @@ func (priest *Priest) AddRaidBuffs(raidBuffs *proto.RaidBuffs) {
 func (priest *Priest) AddPartyBuffs(_ *proto.PartyBuffs) {
 }
 
+func (priest *Priest) RosterBuffs() []core.RosterBuff {
+	buffs := []core.RosterBuff{
+		{
+			Name:      "Power Word: Fortitude",
+			Scope:     proto.RosterEffectScope_RosterEffectScopeRaid,
+			RaidBuffs: &proto.RaidBuffs{PowerWordFortitude: core.MakeTristateValue(true, priest.Talents.ImprovedPowerWordFortitude == 2)},
+		},
+		{
+			Name:      "Shadow Protection",
+			Scope:     proto.RosterEffectScope_RosterEffectScopeRaid,
+			RaidBuffs: &proto.RaidBuffs{ShadowProtection: true},
+		},
+	}
+	if priest.Talents.DivineSpirit {
+		buffs = append(buffs, core.RosterBuff{
+			Name:      "Divine Spirit",
+			Scope:     proto.RosterEffectScope_RosterEffectScopeRaid,
+			RaidBuffs: &proto.RaidBuffs{DivineSpirit: true},
+		})
+	}
+	if priest.Talents.ShadowWeaving == 5 {
+		buffs = append(buffs, core.RosterBuff{
+			Name:    "Shadow Weaving",
+			Scope:   proto.RosterEffectScope_RosterEffectScopeDebuff,
+			Debuffs: &proto.Debuffs{ShadowWeaving: true},
+		})
+	}
+	return buffs
+}
+
 func (priest *Priest) Initialize() {
 	priest.registerMindBlast()
 	priest.registerMindFlay()
@@ -13,18 +13,18 @@ import (
 	"github.com/wowsims/sod/sim/druid/feral"
 	feralTank "github.com/wowsims/sod/sim/druid/tank"
 
-	// restoDruid "github.com/wowsims/sod/sim/druid/restoration"
+	restoDruid "github.com/wowsims/sod/sim/druid/restoration"
 	_ "github.com/wowsims/sod/sim/encounters"
 	dpsHunter "github.com/wowsims/sod/sim/hunter/dps_hunter"
 	dpsMage "github.com/wowsims/sod/sim/mage/dps_mage"
 
-	// holyPaladin "github.com/wowsims/sod/sim/paladin/holy"
+	holyPaladin "github.com/wowsims/sod/sim/paladin/holy"
 	"github.com/wowsims/sod/sim/paladin/protection"
 	// "github.com/wowsims/sod/sim/paladin/retribution"
 	// healingPriest "github.com/wowsims/sod/sim/priest/healing"
 	"github.com/wowsims/sod/sim/priest/shadow"
 
-	// restoShaman "github.com/wowsims/sod/sim/shaman/restoration"
+	restoShaman "github.com/wowsims/sod/sim/shaman/restoration"
 	dpsWarlock "github.com/wowsims/sod/sim/warlock/dps"
 	tankWarlock "github.com/wowsims/sod/sim/warlock/tank"
 	dpsWarrior "github.com/wowsims/sod/sim/warrior/dps_warrior"
@@ -42,11 +42,11 @@ func RegisterAll() {
 	balance.RegisterBalanceDruid()
 	feral.RegisterFeralDruid()
 	feralTank.RegisterFeralTankDruid()
-	// restoDruid.RegisterRestorationDruid()
+	restoDruid.RegisterRestorationDruid()
 	elemental.RegisterElementalShaman()
 	enhancement.RegisterEnhancementShaman()
 	warden.RegisterWardenShaman()
-	// restoShaman.RegisterRestorationShaman()
+	restoShaman.RegisterRestorationShaman()
 	dpsHunter.RegisterDPSHunter()
 	dpsMage.RegisterDPSMage()
 	// healingPriest.RegisterHealingPriest()
@@ -55,7 +55,7 @@ func RegisterAll() {
 	tankrogue.RegisterTankRogue()
 	dpsWarrior.RegisterDpsWarrior()
 	tankWarrior.RegisterTankWarrior()
-	// holyPaladin.RegisterHolyPaladin()
+	holyPaladin.RegisterHolyPaladin()
 	protection.RegisterProtectionPaladin()
 	retribution.RegisterRetributionPaladin()
 	dpsWarlock.RegisterDpsWarlock()
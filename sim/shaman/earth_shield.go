@@ -1,7 +1,13 @@
 package shaman
 
-import "github.com/wowsims/sod/sim/core/proto"
+import (
+	"time"
 
+	"github.com/wowsims/sod/sim/core"
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+// Earth Shield is an elemental shield in SoD, so it can only be placed on the shaman and replaces Lightning/Water Shield.
 func (shaman *Shaman) registerEarthShieldSpell() {
 	if !shaman.HasRune(proto.ShamanRune_RuneLegsEarthShield) {
 		return
@@ -9,61 +15,82 @@ func (shaman *Shaman) registerEarthShieldSpell() {
 
 	shaman.PseudoStats.SpellPushbackMultiplier *= 0.70
 
-	// actionID := core.ActionID{SpellID: 49284}
-	// spCoeff := 0.286
+	actionID := core.ActionID{SpellID: int32(proto.ShamanRune_RuneLegsEarthShield)}
+	baseHealing := shaman.baseRuneAbilityDamage() * 1.45 * (1 + shaman.purificationHealingModifier())
+	spellCoeff := 0.286
+
+	icd := core.Cooldown{
+		Timer:    shaman.NewTimer(),
+		Duration: time.Millisecond * 3500,
+	}
+
+	healSpell := shaman.RegisterSpell(core.SpellConfig{
+		ActionID:       core.ActionID{SpellID: 379},
+		ClassSpellMask: ClassSpellMask_ShamanEarthShieldHeal,
+		SpellSchool:    core.SpellSchoolNature,
+		DefenseType:    core.DefenseTypeMagic,
+		ProcMask:       core.ProcMaskSpellHealing,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagPassiveSpell,
+
+		DamageMultiplier: 1,
+		ThreatMultiplier: 1,
+		BonusCoefficient: spellCoeff,
+
+		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			spell.CalcAndDealHealing(sim, target, baseHealing, spell.OutcomeHealing)
+		},
+	})
 
-	// icd := core.Cooldown{
-	// 	Timer:    shaman.NewTimer(),
-	// 	Duration: time.Millisecond * 3500,
-	// }
+	shaman.EarthShieldAura = shaman.RegisterAura(core.Aura{
+		Label:     "Earth Shield",
+		ActionID:  actionID,
+		Duration:  time.Minute * 10,
+		MaxStacks: 9,
+		OnExpire: func(aura *core.Aura, sim *core.Simulation) {
+			if shaman.ActiveShieldAura == aura {
+				shaman.ActiveShieldAura = nil
+				shaman.ActiveShield = nil
+			}
+		},
+		OnSpellHitTaken: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
+			if !result.Landed() || !icd.IsReady(sim) {
+				return
+			}
 
-	// shaman.EarthShield = shaman.RegisterSpell(core.SpellConfig{
-	// 	ActionID:    actionID,
-	//  SpellClassMask: ClassSpellMask_ShamanEarthShield,
-	// 	SpellSchool: core.SpellSchoolNature,
-	// 	DefenseType: core.DefenseTypeMagic,
-	// 	ProcMask:    core.ProcMaskEmpty,
-	// 	Flags:       core.SpellFlagHelpful | core.SpellFlagAPL,
+			icd.Use(sim)
+			healSpell.Cast(sim, aura.Unit)
+			aura.RemoveStack(sim)
 
-	// 	Cast: core.CastConfig{
-	// 		DefaultCast: core.Cast{
-	// 			GCD: core.GCDDefault,
-	// 		},
-	// 	},
+			if aura.GetStacks() == 0 {
+				aura.Deactivate(sim)
+			}
+		},
+	})
 
-	// 	Hot: core.DotConfig{
-	// 		Aura: core.Aura{
-	// 			Label:    "Earth Shield",
-	// 			ActionID: core.ActionID{SpellID: 379},
-	// 			OnSpellHitTaken: func(aura *core.Aura, sim *core.Simulation, spell *core.Spell, result *core.SpellResult) {
-	// 				if !result.Landed() {
-	// 					return
-	// 				}
-	// 				if !icd.IsReady(sim) {
-	// 					return
-	// 				}
-	// 				icd.Use(sim)
-	// 				shaman.EarthShield.Hot(result.Target).ManualTick(sim)
-	// 			},
-	// 			OnExpire: func(aura *core.Aura, sim *core.Simulation) {
-	// 			},
-	// 		},
-	// 		NumberOfTicks: 6 + shaman.Talents.ImprovedEarthShield,
-	// 		TickLength:    time.Minute*10 + 1, // tick length longer than expire time.
-	// 		OnSnapshot: func(sim *core.Simulation, target *core.Unit, dot *core.Dot, _ bool) {
-	// 			dot.SnapshotBaseDamage = 377 + dot.Spell.HealingPower(target)*spCoeff
-	// 			dot.SnapshotAttackerMultiplier = dot.Spell.CasterHealingMultiplier()
-	// 		},
-	// 		OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
-	// 			dot.CalcAndDealPeriodicSnapshotHealing(sim, target, dot.OutcomeTick)
-	// 		},
-	// 	},
+	shaman.EarthShield = shaman.RegisterSpell(core.SpellConfig{
+		ActionID:       actionID,
+		ClassSpellMask: ClassSpellMask_ShamanEarthShield,
+		SpellSchool:    core.SpellSchoolNature,
+		ProcMask:       core.ProcMaskEmpty,
+		Flags:          core.SpellFlagHelpful | core.SpellFlagAPL,
 
-	// 	DamageMultiplier: 1
-	// 	ThreatMultiplier: 1,
+		ManaCost: core.ManaCostOptions{
+			BaseCost: 0.15,
+		},
+		Cast: core.CastConfig{
+			DefaultCast: core.Cast{
+				GCD: core.GCDDefault,
+			},
+		},
 
-	// 	ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
-	// 		spell.Hot(target).Apply(sim)
-	// 	},
-	// })
+		ApplyEffects: func(sim *core.Simulation, _ *core.Unit, spell *core.Spell) {
+			if shaman.ActiveShieldAura != nil {
+				shaman.ActiveShieldAura.Deactivate(sim)
+			}
+			shaman.ActiveShield = spell
+			shaman.ActiveShieldAura = shaman.EarthShieldAura
+			shaman.EarthShieldAura.Activate(sim)
+			shaman.EarthShieldAura.SetStacks(sim, shaman.EarthShieldAura.MaxStacks)
+		},
+	})
 }
@@ -0,0 +1,52 @@
+package restoration
+
+import (
+	"github.com/wowsims/sod/sim/core"
+	"github.com/wowsims/sod/sim/core/proto"
+	"github.com/wowsims/sod/sim/shaman"
+)
+
+func RegisterRestorationShaman() {
+	core.RegisterAgentFactory(
+		proto.Player_RestorationShaman{},
+		proto.Spec_SpecRestorationShaman,
+		func(character *core.Character, options *proto.Player) core.Agent {
+			return NewRestorationShaman(character, options)
+		},
+		func(player *proto.Player, spec interface{}) {
+			playerSpec, ok := spec.(*proto.Player_RestorationShaman)
+			if !ok {
+				panic("Invalid spec value for Restoration Shaman!")
+			}
+			player.Spec = playerSpec
+		},
+	)
+}
+
+func NewRestorationShaman(character *core.Character, options *proto.Player) *RestorationShaman {
+	resto := &RestorationShaman{
+		Shaman: shaman.NewShaman(character, options.TalentsString),
+	}
+
+	resto.EnableAutoAttacks(resto, core.AutoAttackOptions{
+		MainHand: resto.WeaponFromMainHand(),
+	})
+
+	return resto
+}
+
+type RestorationShaman struct {
+	*shaman.Shaman
+}
+
+func (resto *RestorationShaman) GetShaman() *shaman.Shaman {
+	return resto.Shaman
+}
+
+func (resto *RestorationShaman) Initialize() {
+	resto.Shaman.Initialize()
+}
+
+func (resto *RestorationShaman) Reset(sim *core.Simulation) {
+	resto.Shaman.Reset(sim)
+}
@@ -66,8 +66,9 @@ func (shaman *Shaman) registerRiptideSpell() {
 		ThreatMultiplier: 1,
 
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
-			spell.CalcAndDealHealing(sim, spell.Unit, sim.Roll(baseHealingLow, baseHealingHigh), spell.OutcomeHealingCrit)
-			spell.Hot(spell.Unit).Apply(sim)
+			target = shaman.HealTarget(sim, target)
+			spell.CalcAndDealHealing(sim, target, sim.Roll(baseHealingLow, baseHealingHigh), spell.OutcomeHealingCrit)
+			spell.Hot(target).Apply(sim)
 		},
 	})
 }
@@ -20,6 +20,8 @@ const (
 	ClassSpellMask_ShamanAncestralGuidanceHeal
 	ClassSpellMask_ShamanChainHeal
 	ClassSpellMask_ShamanChainLightning
+	ClassSpellMask_ShamanEarthShield
+	ClassSpellMask_ShamanEarthShieldHeal
 	ClassSpellMask_ShamanEarthShock
 	ClassSpellMask_ShamanFeralSpirit
 	ClassSpellMask_ShamanFireNova
@@ -189,6 +191,7 @@ type Shaman struct {
 
 	// Auras
 	ClearcastingAura     *core.Aura
+	EarthShieldAura      *core.Aura
 	LightningShieldAuras []*core.Aura
 	LoyalBetaAura        *core.Aura
 	MaelstromWeaponAura  *core.Aura
@@ -252,6 +255,28 @@ func (shaman *Shaman) AddRaidBuffs(_ *proto.RaidBuffs) {
 	// Buffs are handled explicitly through APLs now
 }
 
+// Totems are placed by the APL, so this assumes the usual Strength of Earth, Grace of Air and Mana Spring setup.
+func (shaman *Shaman) RosterBuffs() []core.RosterBuff {
+	improvedTotems := shaman.Talents.EnhancingTotems == 2
+	return []core.RosterBuff{
+		{
+			Name:      "Strength of Earth Totem",
+			Scope:     proto.RosterEffectScope_RosterEffectScopeParty,
+			RaidBuffs: &proto.RaidBuffs{StrengthOfEarthTotem: core.MakeTristateValue(true, improvedTotems)},
+		},
+		{
+			Name:      "Grace of Air Totem",
+			Scope:     proto.RosterEffectScope_RosterEffectScopeParty,
+			RaidBuffs: &proto.RaidBuffs{GraceOfAirTotem: core.MakeTristateValue(true, improvedTotems)},
+		},
+		{
+			Name:      "Mana Spring Totem",
+			Scope:     proto.RosterEffectScope_RosterEffectScopeParty,
+			RaidBuffs: &proto.RaidBuffs{ManaSpringTotem: core.MakeTristateValue(true, shaman.Talents.RestorativeTotems == 5)},
+		},
+	}
+}
+
 func (shaman *Shaman) Initialize() {
 	// Core abilities
 	shaman.registerChainLightningSpell()
@@ -24,6 +24,9 @@ func (warlock *Warlock) getRainOfFireBaseConfig(rank int) core.SpellConfig {
 		flags |= core.SpellFlagChanneled
 	}
 
+	// Rain of Fire lands where the target stood when it was cast.
+	var center core.Vector2
+
 	config := core.SpellConfig{
 		ActionID:       core.ActionID{SpellID: spellId},
 		ClassSpellMask: ClassSpellMask_WarlockRainOfFire,
@@ -59,6 +62,9 @@ func (warlock *Warlock) getRainOfFireBaseConfig(rank int) core.SpellConfig {
 			},
 			OnTick: func(sim *core.Simulation, target *core.Unit, dot *core.Dot) {
 				for _, aoeTarget := range sim.Encounter.TargetUnits {
+					if !aoeTarget.IsWithinRadius(center, 8) {
+						continue
+					}
 					dot.CalcAndDealPeriodicSnapshotDamage(sim, aoeTarget, dot.OutcomeTick)
 				}
 
@@ -66,6 +72,7 @@ func (warlock *Warlock) getRainOfFireBaseConfig(rank int) core.SpellConfig {
 		},
 
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
+			center = target.Position
 			spell.AOEDot().Apply(sim)
 		},
 	}
@@ -219,6 +219,25 @@ func (warlock *Warlock) AddRaidBuffs(raidBuffs *proto.RaidBuffs) {
 	))
 }
 
+func (warlock *Warlock) RosterBuffs() []core.RosterBuff {
+	var buffs []core.RosterBuff
+	if warlock.Options.Summon == proto.WarlockOptions_Imp {
+		buffs = append(buffs, core.RosterBuff{
+			Name:      "Blood Pact",
+			Scope:     proto.RosterEffectScope_RosterEffectScopeParty,
+			RaidBuffs: &proto.RaidBuffs{BloodPact: core.MakeTristateValue(true, warlock.Talents.ImprovedImp == 3)},
+		})
+	}
+	if warlock.Talents.ImprovedShadowBolt == 5 {
+		buffs = append(buffs, core.RosterBuff{
+			Name:    "Improved Shadow Bolt",
+			Scope:   proto.RosterEffectScope_RosterEffectScopeDebuff,
+			Debuffs: &proto.Debuffs{ImprovedShadowBolt: true},
+		})
+	}
+	return buffs
+}
+
 func (warlock *Warlock) Reset(sim *core.Simulation) {
 	warlock.setDefaultActivePet()
 	warlock.SacrificedPet = nil
@@ -113,8 +113,10 @@ func (warrior *Warrior) registerCleaveSpell(realismICD *core.Cooldown) {
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
 			curTarget := target
 			for i := int32(0); i < min(targetCount, warrior.CleaveTargetCount); i++ {
-				baseDamage := flatDamageBonus + spell.Unit.MHWeaponDamage(sim, spell.MeleeAttackPower())
-				spell.CalcAndDealDamage(sim, curTarget, baseDamage, spell.OutcomeMeleeWeaponSpecialHitAndCrit)
+				if i == 0 || warrior.IsWithinRange(curTarget, core.MaxMeleeAttackRange) {
+					baseDamage := flatDamageBonus + spell.Unit.MHWeaponDamage(sim, spell.MeleeAttackPower())
+					spell.CalcAndDealDamage(sim, curTarget, baseDamage, spell.OutcomeMeleeWeaponSpecialHitAndCrit)
+				}
 				curTarget = sim.Environment.NextTargetUnit(curTarget)
 			}
 
@@ -182,7 +184,7 @@ func (warrior *Warrior) makeQueueSpellsAndAura(srcSpell *WarriorSpell, realismIC
 }
 
 func (warrior *Warrior) TryHSOrCleave(sim *core.Simulation, mhSwingSpell *core.Spell) *core.Spell {
-	if !warrior.curQueueAura.IsActive() {
+	if !warrior.curQueueAura.IsActive() || !warrior.HasQueueLanded(sim, warrior.curQueueAura.StartedAt()) {
 		return mhSwingSpell
 	}
 
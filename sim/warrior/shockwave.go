@@ -42,9 +42,11 @@ func (warrior *Warrior) registerShockwaveSpell() {
 
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, spell *core.Spell) {
 			baseDamage := apCoef * spell.MeleeAttackPower()
-			for _, aoeTarget := range sim.Encounter.TargetUnits {
+			for _, aoeTarget := range warrior.GetOpponents() {
 				// Shockwave can miss and be blocked, but it can't be dodged or parried
-				spell.CalcAndDealDamage(sim, aoeTarget, baseDamage, spell.OutcomeMeleeSpecialNoDodgeParry)
+				if result := spell.CalcAndDealDamage(sim, aoeTarget, baseDamage, spell.OutcomeMeleeSpecialNoDodgeParry); result.Landed() {
+					aoeTarget.ApplyCrowdControl(sim, core.CrowdControlStun, time.Second*4)
+				}
 			}
 		},
 	})
@@ -174,6 +174,26 @@ func (warrior *Warrior) AddRaidBuffs(raidBuffs *proto.RaidBuffs) {
 func (warrior *Warrior) AddPartyBuffs(_ *proto.PartyBuffs) {
 }
 
+func (warrior *Warrior) RosterBuffs() []core.RosterBuff {
+	return []core.RosterBuff{
+		{
+			Name:      "Battle Shout",
+			Scope:     proto.RosterEffectScope_RosterEffectScopeParty,
+			RaidBuffs: &proto.RaidBuffs{BattleShout: core.MakeTristateValue(true, warrior.Talents.ImprovedBattleShout == 5)},
+		},
+		{
+			Name:    "Sunder Armor",
+			Scope:   proto.RosterEffectScope_RosterEffectScopeDebuff,
+			Debuffs: &proto.Debuffs{SunderArmor: true},
+		},
+		{
+			Name:    "Demoralizing Shout",
+			Scope:   proto.RosterEffectScope_RosterEffectScopeDebuff,
+			Debuffs: &proto.Debuffs{DemoralizingShout: core.MakeTristateValue(true, warrior.Talents.ImprovedDemoralizingShout == 5)},
+		},
+	}
+}
+
 func (warrior *Warrior) RegisterSpell(stanceMask Stance, config core.SpellConfig) *WarriorSpell {
 	ws := &WarriorSpell{
 		StanceMask: stanceMask,
@@ -44,6 +44,9 @@ func (warrior *Warrior) registerWhirlwindSpell() {
 
 		ApplyEffects: func(sim *core.Simulation, target *core.Unit, _ *core.Spell) {
 			for _, aoeTarget := range sim.Encounter.TargetUnits {
+				if !warrior.IsWithinRange(aoeTarget, 8) {
+					continue
+				}
 				warrior.WhirlwindMH.Cast(sim, aoeTarget)
 				if canHitOffhand && warrior.IsEnraged() {
 					warrior.WhirlwindOH.Cast(sim, aoeTarget)
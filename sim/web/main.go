@@ -20,6 +20,7 @@ import (
 
 	uuid "github.com/google/uuid"
 	"github.com/pkg/browser"
+	"github.com/wowsims/sod/assets/database"
 	dist "github.com/wowsims/sod/binary_dist"
 	"github.com/wowsims/sod/sim"
 	"github.com/wowsims/sod/sim/core"
@@ -106,6 +107,30 @@ var handlers = map[string]apiHandler{
 	"/computeStats": {msg: func() googleProto.Message { return &proto.ComputeStatsRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
 		return core.ComputeStats(msg.(*proto.ComputeStatsRequest))
 	}},
+	"/databaseSearch": {msg: func() googleProto.Message { return &proto.DatabaseSearchRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
+		return core.SearchDatabase(loadUIDatabase(), msg.(*proto.DatabaseSearchRequest))
+	}},
+	"/statCurve": {msg: func() googleProto.Message { return &proto.StatCurveRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
+		return core.StatCurve(msg.(*proto.StatCurveRequest))
+	}},
+	"/raidComposition": {msg: func() googleProto.Message { return &proto.RaidCompositionRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
+		return core.RaidComposition(msg.(*proto.RaidCompositionRequest))
+	}},
+	"/raidNight": {msg: func() googleProto.Message { return &proto.RaidNightRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
+		return core.RaidNight(msg.(*proto.RaidNightRequest))
+	}},
+	"/consumableOptimizer": {msg: func() googleProto.Message { return &proto.ConsumableOptimizerRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
+		return core.ConsumableOptimizer(msg.(*proto.ConsumableOptimizerRequest))
+	}},
+	"/duel": {msg: func() googleProto.Message { return &proto.DuelRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
+		return core.Duel(msg.(*proto.DuelRequest))
+	}},
+	"/resultDiff": {msg: func() googleProto.Message { return &proto.ResultDiffRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
+		return core.ResultDiff(msg.(*proto.ResultDiffRequest))
+	}},
+	"/importCharacter": {msg: func() googleProto.Message { return &proto.CharacterImportRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
+		return core.ImportCharacter(msg.(*proto.CharacterImportRequest))
+	}},
 	"/abortById": {msg: func() googleProto.Message { return &proto.AbortRequest{} }, handle: func(msg googleProto.Message) googleProto.Message {
 		requestId := msg.(*proto.AbortRequest).RequestId
 		triggered := simsignals.AbortById(requestId)
@@ -113,6 +138,9 @@ var handlers = map[string]apiHandler{
 	}},
 }
 
+// The full UI database is only decoded on the first search request.
+var loadUIDatabase = sync.OnceValue(database.Load)
+
 var asyncAPIHandlers = map[string]asyncAPIHandler{
 	"/raidSimAsync": {msg: func() googleProto.Message { return &proto.RaidSimRequest{} }, handle: func(msg googleProto.Message, reporter chan *proto.ProgressMetrics, requestId string) {
 		core.RunRaidSimConcurrentAsync(msg.(*proto.RaidSimRequest), reporter, requestId)
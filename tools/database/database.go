@@ -326,6 +326,7 @@ func ReadDatabaseFromJson(jsonStr string) *WowDatabase {
 		Items:          sliceToMap(dbProto.Items),
 		RandomSuffixes: sliceToMap(dbProto.RandomSuffixes),
 		Enchants:       enchants,
+		Runes:          sliceToMap(dbProto.Runes),
 		Zones:          sliceToMap(dbProto.Zones),
 		Npcs:           sliceToMap(dbProto.Npcs),
 		Factions:       sliceToMap(dbProto.Factions),
@@ -343,7 +344,8 @@ func (db *WowDatabase) WriteBinary(binFilePath string) {
 	uidb := db.ToUIProto()
 
 	// Write database as a binary file.
-	protoBytes, err := googleProto.Marshal(uidb)
+	// Deterministic so identical inputs always produce identical files.
+	protoBytes, err := googleProto.MarshalOptions{Deterministic: true}.Marshal(uidb)
 	if err != nil {
 		log.Fatalf("[ERROR] Failed to marshal db: %s", err.Error())
 	}
@@ -0,0 +1,108 @@
+package database
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/exp/maps"
+	googleProto "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+type DatabaseDiff struct {
+	Added   []string
+	Removed []string
+	Changed []string
+}
+
+func (diff *DatabaseDiff) IsEmpty() bool {
+	return len(diff.Added) == 0 && len(diff.Removed) == 0 && len(diff.Changed) == 0
+}
+
+// Compares two generated databases entry by entry.
+func DiffDatabases(before, after *WowDatabase) *DatabaseDiff {
+	diff := &DatabaseDiff{}
+
+	diffEntries(diff, "item", before.Items, after.Items, idString, compareIds)
+	diffEntries(diff, "enchant", before.Enchants, after.Enchants, func(key EnchantDBKey) string {
+		return fmt.Sprintf("%d (item %d, spell %d)", key.EffectID, key.ItemID, key.SpellID)
+	}, func(a, b EnchantDBKey) int {
+		if a.EffectID != b.EffectID {
+			return compareIds(a.EffectID, b.EffectID)
+		}
+		if a.ItemID != b.ItemID {
+			return compareIds(a.ItemID, b.ItemID)
+		}
+		return compareIds(a.SpellID, b.SpellID)
+	})
+	diffEntries(diff, "rune", before.Runes, after.Runes, idString, compareIds)
+	diffEntries(diff, "random suffix", before.RandomSuffixes, after.RandomSuffixes, idString, compareIds)
+	diffEntries(diff, "zone", before.Zones, after.Zones, idString, compareIds)
+	diffEntries(diff, "npc", before.Npcs, after.Npcs, idString, compareIds)
+	diffEntries(diff, "faction", before.Factions, after.Factions, idString, compareIds)
+	diffEntries(diff, "item icon", before.ItemIcons, after.ItemIcons, idString, compareIds)
+	diffEntries(diff, "spell icon", before.SpellIcons, after.SpellIcons, idString, compareIds)
+
+	return diff
+}
+
+func idString(id int32) string {
+	return fmt.Sprintf("%d", id)
+}
+
+func compareIds(a, b int32) int {
+	return int(a - b)
+}
+
+type namedMessage interface {
+	googleProto.Message
+	GetName() string
+}
+
+func diffEntries[K comparable, V namedMessage](diff *DatabaseDiff, kind string, before, after map[K]V, keyString func(K) string, compare func(K, K) int) {
+	keys := maps.Keys(before)
+	for key := range after {
+		if _, ok := before[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.SortFunc(keys, compare)
+
+	for _, key := range keys {
+		oldEntry, inBefore := before[key]
+		newEntry, inAfter := after[key]
+		switch {
+		case !inBefore:
+			diff.Added = append(diff.Added, fmt.Sprintf("%s %s %s", kind, keyString(key), newEntry.GetName()))
+		case !inAfter:
+			diff.Removed = append(diff.Removed, fmt.Sprintf("%s %s %s", kind, keyString(key), oldEntry.GetName()))
+		default:
+			if changes := cmp.Diff(oldEntry, newEntry, protocmp.Transform()); changes != "" {
+				diff.Changed = append(diff.Changed, fmt.Sprintf("%s %s %s:\n%s", kind, keyString(key), newEntry.GetName(), changes))
+			}
+		}
+	}
+}
+
+func (diff *DatabaseDiff) Report() string {
+	buffer := new(bytes.Buffer)
+	fmt.Fprintf(buffer, "Added: %d, Removed: %d, Changed: %d\n", len(diff.Added), len(diff.Removed), len(diff.Changed))
+
+	writeSection := func(title string, lines []string) {
+		if len(lines) == 0 {
+			return
+		}
+		fmt.Fprintf(buffer, "\n== %s ==\n", title)
+		for _, line := range lines {
+			buffer.WriteString(line)
+			buffer.WriteString("\n")
+		}
+	}
+	writeSection("Added", diff.Added)
+	writeSection("Removed", diff.Removed)
+	writeSection("Changed", diff.Changed)
+
+	return buffer.String()
+}
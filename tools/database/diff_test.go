@@ -0,0 +1,40 @@
+package database
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+func TestDiffDatabases(t *testing.T) {
+	before := NewWowDatabase()
+	before.Items[1] = &proto.UIItem{Id: 1, Name: "Kept"}
+	before.Items[2] = &proto.UIItem{Id: 2, Name: "Changed", Phase: 1}
+	before.Items[3] = &proto.UIItem{Id: 3, Name: "Removed"}
+	before.Enchants[EnchantDBKey{EffectID: 4, SpellID: 5}] = &proto.UIEnchant{EffectId: 4, SpellId: 5, Name: "Enchant"}
+
+	after := NewWowDatabase()
+	after.Items[1] = &proto.UIItem{Id: 1, Name: "Kept"}
+	after.Items[2] = &proto.UIItem{Id: 2, Name: "Changed", Phase: 2}
+	after.Items[6] = &proto.UIItem{Id: 6, Name: "Added"}
+	after.Enchants[EnchantDBKey{EffectID: 4, SpellID: 5}] = &proto.UIEnchant{EffectId: 4, SpellId: 5, Name: "Enchant"}
+
+	diff := DiffDatabases(before, after)
+	if len(diff.Added) != 1 || diff.Added[0] != "item 6 Added" {
+		t.Fatalf("Expected item 6 to be added, got %q", diff.Added)
+	}
+	if len(diff.Removed) != 1 || diff.Removed[0] != "item 3 Removed" {
+		t.Fatalf("Expected item 3 to be removed, got %q", diff.Removed)
+	}
+	if len(diff.Changed) != 1 || !strings.HasPrefix(diff.Changed[0], "item 2 Changed:") || !strings.Contains(diff.Changed[0], "phase") {
+		t.Fatalf("Expected the phase of item 2 to change, got %q", diff.Changed)
+	}
+	if report := diff.Report(); !strings.HasPrefix(report, "Added: 1, Removed: 1, Changed: 1\n") {
+		t.Fatalf("Expected a summary line, got %q", report)
+	}
+
+	if diff := DiffDatabases(after, after); !diff.IsEmpty() {
+		t.Fatalf("Expected no differences between identical databases, got %v", diff)
+	}
+}
@@ -0,0 +1,154 @@
+package database
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+
+	"github.com/wowsims/sod/sim/core"
+	"github.com/wowsims/sod/sim/core/proto"
+	"github.com/wowsims/sod/sim/core/stats"
+)
+
+// Items which have effect tooltips that are intentionally not simmed, e.g. pure utility.
+var EffectAuditIgnoreList = map[int32]bool{}
+
+type EffectAuditKind string
+
+const (
+	EffectAuditItem    EffectAuditKind = "item"
+	EffectAuditEnchant EffectAuditKind = "enchant"
+	EffectAuditSet     EffectAuditKind = "set"
+)
+
+type EffectAuditEntry struct {
+	Kind  EffectAuditKind
+	ID    int32
+	Name  string
+	Phase int32
+	Type  proto.ItemType
+
+	// Effect lines from the tooltip, when available.
+	Effects []string
+}
+
+// Cross-references every item, enchant and item set in the database against the registered
+// sim effects and returns the ones which are not implemented.
+// Sim effects must already be registered, i.e. sim.RegisterAll() must have been called.
+func AuditEffects(db *WowDatabase, itemTooltips map[int32]WowheadItemResponse) []EffectAuditEntry {
+	var entries []EffectAuditEntry
+
+	for _, item := range db.Items {
+		if EffectAuditIgnoreList[item.Id] || core.IsItemEffectImplemented(item.Id) {
+			continue
+		}
+
+		var effects []string
+		if tooltip, ok := itemTooltips[item.Id]; ok {
+			effects = tooltip.GetEffectLines()
+		} else if item.HasEffect {
+			effects = []string{"(no tooltip available)"}
+		}
+		if len(effects) == 0 {
+			continue
+		}
+
+		entries = append(entries, EffectAuditEntry{
+			Kind:    EffectAuditItem,
+			ID:      item.Id,
+			Name:    item.Name,
+			Phase:   item.Phase,
+			Type:    item.Type,
+			Effects: effects,
+		})
+	}
+
+	for _, enchant := range db.Enchants {
+		if core.IsEnchantImplemented(core.Enchant{EffectID: enchant.EffectId, Stats: stats.FromFloatArray(enchant.Stats)}) {
+			continue
+		}
+		entries = append(entries, EffectAuditEntry{
+			Kind:  EffectAuditEnchant,
+			ID:    enchant.EffectId,
+			Name:  enchant.Name,
+			Phase: enchant.Phase,
+			Type:  enchant.Type,
+		})
+	}
+
+	seenSets := make(map[string]bool)
+	for _, item := range db.Items {
+		if item.SetName == "" || seenSets[item.SetName] {
+			continue
+		}
+		seenSets[item.SetName] = true
+		if core.HasItemSet(item.SetId, item.SetName) {
+			continue
+		}
+
+		var effects []string
+		if tooltip, ok := itemTooltips[item.Id]; ok {
+			effects = tooltip.GetSetBonusLines()
+		}
+		entries = append(entries, EffectAuditEntry{
+			Kind:    EffectAuditSet,
+			ID:      item.SetId,
+			Name:    item.SetName,
+			Phase:   item.Phase,
+			Effects: effects,
+		})
+	}
+
+	slices.SortFunc(entries, func(a, b EffectAuditEntry) int {
+		if a.Phase != b.Phase {
+			return int(a.Phase - b.Phase)
+		}
+		if a.Kind != b.Kind {
+			return slices.Index([]EffectAuditKind{EffectAuditItem, EffectAuditEnchant, EffectAuditSet}, a.Kind) -
+				slices.Index([]EffectAuditKind{EffectAuditItem, EffectAuditEnchant, EffectAuditSet}, b.Kind)
+		}
+		if a.Type != b.Type {
+			return int(a.Type - b.Type)
+		}
+		return int(a.ID - b.ID)
+	})
+
+	return entries
+}
+
+// Formats audit entries as a human-readable report grouped by phase and slot.
+func EffectAuditReport(entries []EffectAuditEntry) string {
+	buffer := new(bytes.Buffer)
+
+	counts := make(map[int32]int)
+	for _, entry := range entries {
+		counts[entry.Phase]++
+	}
+
+	lastPhase := int32(-1)
+	lastHeader := ""
+	for _, entry := range entries {
+		if entry.Phase != lastPhase {
+			fmt.Fprintf(buffer, "\n== Phase %d (%d unimplemented) ==\n", entry.Phase, counts[entry.Phase])
+			lastPhase = entry.Phase
+			lastHeader = ""
+		}
+
+		header := string(entry.Kind)
+		if entry.Kind != EffectAuditSet {
+			header += " " + entry.Type.String()
+		}
+		if header != lastHeader {
+			fmt.Fprintf(buffer, "-- %s\n", header)
+			lastHeader = header
+		}
+
+		fmt.Fprintf(buffer, "%d\t%s\n", entry.ID, entry.Name)
+		for _, effect := range entry.Effects {
+			fmt.Fprintf(buffer, "\t\t%s\n", effect)
+		}
+	}
+
+	fmt.Fprintf(buffer, "\nTotal: %d\n", len(entries))
+	return buffer.String()
+}
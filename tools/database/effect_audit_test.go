@@ -0,0 +1,66 @@
+package database
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/wowsims/sod/sim/core"
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+func TestGetEffectLines(t *testing.T) {
+	item := WowheadItemResponse{
+		ID: 1,
+		Tooltip: `<span class="q2">Equip: Increases damage and healing done by magical spells and effects by up to 20.</span>` +
+			`<span class="q2">Equip: Your spells have a chance to <a href="/spell=1">restore 100 mana</a>.</span>` +
+			`<span class="q2">Use: Increases attack power by 200 for 20 sec.</span>` +
+			`<span class="q2">Chance on hit: Blasts the target for 50 Fire damage.</span>` +
+			`<span class="q">(2) Set : Improves your chance to hit by 1%.</span>`,
+	}
+
+	expected := []string{
+		"Equip: Your spells have a chance to restore 100 mana.",
+		"Use: Increases attack power by 200 for 20 sec.",
+		"Chance on hit: Blasts the target for 50 Fire damage.",
+	}
+	if lines := item.GetEffectLines(); !slices.Equal(lines, expected) {
+		t.Fatalf("Expected effect lines %q, got %q", expected, lines)
+	}
+
+	statsOnly := WowheadItemResponse{Tooltip: `<span class="q2">Equip: Improves your chance to hit by 1%.</span>`}
+	if lines := statsOnly.GetEffectLines(); len(lines) != 0 {
+		t.Fatalf("Expected no effect lines for a stat equip line, got %q", lines)
+	}
+}
+
+func TestAuditEffects(t *testing.T) {
+	const implementedID, unimplementedID, untooltippedID, statStickID = 9000001, 9000002, 9000003, 9000004
+	core.NewItemEffect(implementedID, func(agent core.Agent) {})
+
+	db := NewWowDatabase()
+	for _, id := range []int32{implementedID, unimplementedID, untooltippedID, statStickID} {
+		db.Items[id] = &proto.UIItem{Id: id, Phase: 1, Type: proto.ItemType_ItemTypeTrinket, HasEffect: id != statStickID}
+	}
+	db.Enchants[EnchantDBKey{EffectID: 9000005}] = &proto.UIEnchant{EffectId: 9000005, Phase: 1, Stats: make([]float64, 3)}
+	db.Enchants[EnchantDBKey{EffectID: 9000006}] = &proto.UIEnchant{EffectId: 9000006, Phase: 1, Stats: []float64{0, 0, 10}}
+
+	useLine := `<span class="q2">Use: Increases attack power by 200 for 20 sec.</span>`
+	tooltips := map[int32]WowheadItemResponse{
+		implementedID:   {ID: implementedID, Tooltip: useLine},
+		unimplementedID: {ID: unimplementedID, Tooltip: useLine},
+	}
+
+	entries := AuditEffects(db, tooltips)
+	if len(entries) != 3 {
+		t.Fatalf("Expected 3 unimplemented entries, got %v", entries)
+	}
+	if entry := entries[0]; entry.Kind != EffectAuditItem || entry.ID != unimplementedID || !slices.Equal(entry.Effects, []string{"Use: Increases attack power by 200 for 20 sec."}) {
+		t.Fatalf("Expected the unimplemented item with its tooltip effect first, got %v", entry)
+	}
+	if entry := entries[1]; entry.Kind != EffectAuditItem || entry.ID != untooltippedID || !slices.Equal(entry.Effects, []string{"(no tooltip available)"}) {
+		t.Fatalf("Expected the item flagged in the database without a tooltip second, got %v", entry)
+	}
+	if entry := entries[2]; entry.Kind != EffectAuditEnchant || entry.ID != 9000005 {
+		t.Fatalf("Expected the enchant without stats or effect last, got %v", entry)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"os"
 	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/wowsims/sod/sim"
 	"github.com/wowsims/sod/sim/core"
@@ -17,6 +18,7 @@ import (
 	_ "github.com/wowsims/sod/sim/encounters" // Needed for preset encounters.
 	"github.com/wowsims/sod/tools"
 	"github.com/wowsims/sod/tools/database"
+	"google.golang.org/protobuf/encoding/protojson"
 )
 
 // To do a full re-scrape, delete the previous output file first.
@@ -31,12 +33,30 @@ import (
 // Lastly run the following to generate db.json (ensure to delete cached versions and/or rebuild for copying of assets during local development)
 // Note: This does not make network requests, only regenerates core db binary and json files from existing inputs
 // go run ./tools/database/gen_db -outDir=assets -gen=db
+//
+// Extra JSON patch files (UIDatabasePatch in protojson format) can be applied on top of the Go overrides,
+// and the inputs can be read from a different directory, e.g. for private data. The directory needs the same
+// layout as assets/db_inputs, including the talent trees at <inputsDir>/../../ui/core/talents/trees:
+// go run ./tools/database/gen_db -outDir=assets -gen=db -inputsDir=private/assets/db_inputs -patches=private/ptr.json
+//
+// To write the Go overrides out as a patch file:
+// go run ./tools/database/gen_db -outDir=assets -gen=export-overrides
+//
+// To compare two generated databases:
+// go run ./tools/database/gen_db -gen=diff -before=old/db.json -after=assets/database/db.json
+
+// To list items, enchants and sets whose effects are not implemented in the sim:
+// go run ./tools/database/gen_db -outDir=assets -gen=effect-audit
 
 var exactId = flag.Int("id", 0, "ID to scan for")
 var minId = flag.Int("minid", 1, "Minimum ID to scan for")
 var maxId = flag.Int("maxid", 31000, "Maximum ID to scan for")
 var outDir = flag.String("outDir", "assets", "Path to output directory for writing generated .go files.")
-var genAsset = flag.String("gen", "", "Asset to generate. Valid values are 'db', 'atlasloot', 'wowhead-items', 'wowhead-spells', 'wowhead-itemdb', 'wotlk-items', and 'wago-db2-items'")
+var inputsDirFlag = flag.String("inputsDir", "", "Path to the db inputs directory. Defaults to <outDir>/db_inputs. Talent trees are read from <inputsDir>/../../ui/core/talents/trees.")
+var patchFiles = flag.String("patches", "", "Comma-separated list of JSON patch files to apply when generating the db.")
+var diffBefore = flag.String("before", "", "Path to the old db.json, for -gen=diff.")
+var diffAfter = flag.String("after", "", "Path to the new db.json, for -gen=diff.")
+var genAsset = flag.String("gen", "", "Asset to generate. Valid values are 'db', 'diff', 'export-overrides', 'effect-audit', 'atlasloot', 'wowhead-items', 'wowhead-spells', 'wowhead-itemdb', 'wotlk-items', and 'wago-db2-items'")
 
 func main() {
 	flag.Parse()
@@ -52,8 +72,26 @@ func main() {
 
 	dbDir := fmt.Sprintf("%s/database", *outDir)
 	inputsDir := fmt.Sprintf("%s/db_inputs", *outDir)
+	if *inputsDirFlag != "" {
+		inputsDir = *inputsDirFlag
+	}
 
-	if *genAsset == "atlasloot" {
+	if *genAsset == "diff" {
+		if *diffBefore == "" || *diffAfter == "" {
+			panic("-before and -after are required for -gen=diff")
+		}
+		before := database.ReadDatabaseFromJson(tools.ReadFile(*diffBefore))
+		after := database.ReadDatabaseFromJson(tools.ReadFile(*diffAfter))
+		fmt.Print(database.DiffDatabases(before, after).Report())
+		return
+	} else if *genAsset == "export-overrides" {
+		output, err := protojson.MarshalOptions{Multiline: true, Indent: "  "}.Marshal(database.OverridesAsPatch())
+		if err != nil {
+			log.Fatalf("failed to marshal overrides: %s", err)
+		}
+		tools.WriteFile(fmt.Sprintf("%s/overrides_patch.json", inputsDir), string(output))
+		return
+	} else if *genAsset == "atlasloot" {
 		db := database.ReadAtlasLootData(inputsDir)
 		db.WriteJson(fmt.Sprintf("%s/atlasloot_db.json", inputsDir))
 		return
@@ -69,10 +107,31 @@ func main() {
 	} else if *genAsset == "wago-db2-items" {
 		tools.WriteFile(fmt.Sprintf("%s/wago_db2_items.csv", inputsDir), tools.ReadWebRequired("https://wago.tools/db2/ItemSparse/csv?build=1.15.7.60277"))
 		return
+	} else if *genAsset == "effect-audit" {
+		sim.RegisterAll()
+		itemTooltips := database.NewWowheadItemTooltipManager(fmt.Sprintf("%s/wowhead_item_tooltips.csv", inputsDir)).Read()
+		db := database.ReadDatabaseFromJson(tools.ReadFile(fmt.Sprintf("%s/db.json", dbDir)))
+		fmt.Print(database.EffectAuditReport(database.AuditEffects(db, itemTooltips)))
+		return
 	} else if *genAsset != "db" {
 		panic("Invalid gen value")
 	}
 
+	// The db step must be reproducible from the local inputs alone.
+	tools.DisableWebRequests()
+
+	var patches []*proto.UIDatabasePatch
+	if *patchFiles != "" {
+		for _, patchFile := range strings.Split(*patchFiles, ",") {
+			patch, err := database.ReadDatabasePatch(patchFile)
+			if err != nil {
+				log.Fatal(err)
+			}
+			database.ApplyPatchFilterLists(patch)
+			patches = append(patches, patch)
+		}
+	}
+
 	itemTooltips := database.NewWowheadItemTooltipManager(fmt.Sprintf("%s/wowhead_item_tooltips.csv", inputsDir)).Read()
 	spellTooltips := database.NewWowheadSpellTooltipManager(fmt.Sprintf("%s/wowhead_spell_tooltips.csv", inputsDir)).Read()
 	runeTooltips := database.NewWowheadSpellTooltipManager(fmt.Sprintf("%s/wowhead_rune_tooltips.csv", inputsDir)).Read()
@@ -173,6 +232,9 @@ func main() {
 	db.MergeItems(database.ItemOverrides)
 	db.MergeEnchants(database.EnchantOverrides)
 	db.MergeRunes(database.RuneOverrides)
+	for _, patch := range patches {
+		db.ApplyPatch(patch)
+	}
 	ApplyGlobalFilters(db)
 	AttachFactionInformation(db, wagoItems)
 
@@ -225,6 +287,9 @@ func main() {
 	}
 
 	db.MergeSpellIcons(database.SpellIconoverrides)
+	for _, patch := range patches {
+		db.MergeSpellIcons(patch.SpellIcons)
+	}
 
 	atlasDBProto := atlaslootDB.ToUIProto()
 	db.MergeZones(atlasDBProto.Zones)
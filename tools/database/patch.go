@@ -0,0 +1,161 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/wowsims/sod/sim/core/proto"
+	"github.com/wowsims/sod/sim/core/stats"
+	"golang.org/x/exp/maps"
+	"google.golang.org/protobuf/encoding/protojson"
+	googleProto "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Reads a UIDatabasePatch from a JSON file. Unknown fields and invalid enum names are
+// rejected, so typos are reported instead of silently ignored.
+func ReadDatabasePatch(filePath string) (*proto.UIDatabasePatch, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read patch %s: %w", filePath, err)
+	}
+
+	patch := &proto.UIDatabasePatch{}
+	if err := protojson.Unmarshal(data, patch); err != nil {
+		return nil, fmt.Errorf("failed to parse patch %s: %w", filePath, err)
+	}
+
+	if err := ValidateDatabasePatch(patch); err != nil {
+		return nil, fmt.Errorf("invalid patch %s: %w", filePath, err)
+	}
+	return patch, nil
+}
+
+// Checks the invariants the rest of the database tooling relies on.
+func ValidateDatabasePatch(patch *proto.UIDatabasePatch) error {
+	var errs []error
+
+	for i, item := range patch.Items {
+		if item.Id == 0 {
+			errs = append(errs, fmt.Errorf("items[%d]: id is required", i))
+		}
+		if len(item.Stats) > int(stats.Len) {
+			errs = append(errs, fmt.Errorf("items[%d] (%d): %d stats given, max is %d", i, item.Id, len(item.Stats), stats.Len))
+		}
+		if len(item.WeaponSkills) > int(stats.WeaponSkillLen) {
+			errs = append(errs, fmt.Errorf("items[%d] (%d): %d weapon skills given, max is %d", i, item.Id, len(item.WeaponSkills), stats.WeaponSkillLen))
+		}
+	}
+
+	// Matches the requirements documented in enchant_overrides.go.
+	for i, enchant := range patch.Enchants {
+		if enchant.EffectId == 0 || enchant.SpellId == 0 {
+			errs = append(errs, fmt.Errorf("enchants[%d]: effectId and spellId are required", i))
+		}
+		if len(enchant.Stats) > int(stats.Len) {
+			errs = append(errs, fmt.Errorf("enchants[%d] (%d): %d stats given, max is %d", i, enchant.EffectId, len(enchant.Stats), stats.Len))
+		}
+	}
+
+	for i, rune := range patch.Runes {
+		if rune.Id == 0 {
+			errs = append(errs, fmt.Errorf("runes[%d]: id is required", i))
+		}
+	}
+
+	for i, icon := range patch.SpellIcons {
+		if icon.Id == 0 {
+			errs = append(errs, fmt.Errorf("spellIcons[%d]: id is required", i))
+		}
+	}
+
+	itemFields := (&proto.UIItem{}).ProtoReflect().Descriptor().Fields()
+	for i, reset := range patch.ItemResets {
+		if reset.Id == 0 {
+			errs = append(errs, fmt.Errorf("itemResets[%d]: id is required", i))
+		}
+		for _, name := range reset.Fields {
+			if fd := itemFields.ByName(protoreflect.Name(name)); fd == nil || fd.Number() == 1 {
+				errs = append(errs, fmt.Errorf("itemResets[%d] (%d): unknown or id field %q", i, reset.Id, name))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Adds the patch's allow/deny lists to ItemAllowList and ItemDenyList.
+// Must be called before any filters are applied.
+func ApplyPatchFilterLists(patch *proto.UIDatabasePatch) {
+	for _, id := range patch.AllowedItemIds {
+		ItemAllowList[id] = struct{}{}
+	}
+	for _, id := range patch.DeniedItemIds {
+		ItemDenyList[id] = struct{}{}
+	}
+}
+
+// Merges the patch entries into db, with the same semantics as the Go overrides except that
+// repeated fields replace the existing lists. Spell icons are generated later in the pipeline,
+// so they need to be merged separately.
+func (db *WowDatabase) ApplyPatch(patch *proto.UIDatabasePatch) {
+	for _, reset := range patch.ItemResets {
+		if item, ok := db.Items[reset.Id]; ok {
+			fields := item.ProtoReflect().Descriptor().Fields()
+			for _, name := range reset.Fields {
+				item.ProtoReflect().Clear(fields.ByName(protoreflect.Name(name)))
+			}
+		}
+	}
+
+	for _, item := range patch.Items {
+		if dst, ok := db.Items[item.Id]; ok {
+			clearReplacedLists(dst, item)
+		}
+		db.MergeItem(item)
+	}
+	for _, enchant := range patch.Enchants {
+		if dst, ok := db.Enchants[EnchantToDBKey(enchant)]; ok {
+			clearReplacedLists(dst, enchant)
+		}
+		db.MergeEnchant(enchant)
+	}
+	for _, rune := range patch.Runes {
+		if dst, ok := db.Runes[rune.Id]; ok {
+			clearReplacedLists(dst, rune)
+		}
+		db.MergeRune(rune)
+	}
+
+	for _, id := range patch.RemovedRuneIds {
+		delete(db.Runes, id)
+	}
+}
+
+// googleProto.Merge appends lists, so clears the lists of dst which src is about to set.
+func clearReplacedLists(dst, src googleProto.Message) {
+	dstReflect := dst.ProtoReflect()
+	src.ProtoReflect().Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		if fd.IsList() || fd.IsMap() {
+			dstReflect.Clear(fd)
+		}
+		return true
+	})
+}
+
+// Returns the Go source overrides in patch form, e.g. for moving them to a JSON file.
+func OverridesAsPatch() *proto.UIDatabasePatch {
+	patch := &proto.UIDatabasePatch{
+		Items:      ItemOverrides,
+		Enchants:   EnchantOverrides,
+		Runes:      RuneOverrides,
+		SpellIcons: SpellIconoverrides,
+	}
+	patch.AllowedItemIds = maps.Keys(ItemAllowList)
+	slices.Sort(patch.AllowedItemIds)
+	patch.DeniedItemIds = maps.Keys(ItemDenyList)
+	slices.Sort(patch.DeniedItemIds)
+	return patch
+}
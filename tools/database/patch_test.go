@@ -0,0 +1,73 @@
+package database
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/wowsims/sod/sim/core/proto"
+)
+
+func TestApplyPatch(t *testing.T) {
+	db := NewWowDatabase()
+	db.Items[1] = &proto.UIItem{
+		Id:             1,
+		Name:           "Old Name",
+		Phase:          2,
+		Unique:         true,
+		ClassAllowlist: []proto.Class{proto.Class_ClassMage},
+		Sources:        []*proto.UIItemSource{{Source: &proto.UIItemSource_Drop{Drop: &proto.DropSource{NpcId: 10}}}},
+	}
+	db.Items[2] = &proto.UIItem{Id: 2, Name: "Untouched", ClassAllowlist: []proto.Class{proto.Class_ClassMage}}
+	db.Runes[3] = &proto.UIRune{Id: 3, Name: "Rune", ClassAllowlist: []proto.Class{proto.Class_ClassMage}}
+	db.Runes[4] = &proto.UIRune{Id: 4, Name: "Removed Rune"}
+
+	patch := &proto.UIDatabasePatch{
+		Items: []*proto.UIItem{
+			{Id: 1, Name: "New Name", ClassAllowlist: []proto.Class{proto.Class_ClassPriest}},
+			{Id: 5, Name: "Added"},
+		},
+		Runes:          []*proto.UIRune{{Id: 3, ClassAllowlist: []proto.Class{proto.Class_ClassWarlock}}},
+		RemovedRuneIds: []int32{4},
+		ItemResets:     []*proto.UIDatabasePatch_FieldReset{{Id: 1, Fields: []string{"unique", "sources"}}},
+	}
+	if err := ValidateDatabasePatch(patch); err != nil {
+		t.Fatalf("Expected a valid patch, got %s", err)
+	}
+	db.ApplyPatch(patch)
+
+	item := db.Items[1]
+	if item.Name != "New Name" || item.Phase != 2 {
+		t.Fatalf("Expected the name to be replaced and the phase kept, got %q and %d", item.Name, item.Phase)
+	}
+	if !slices.Equal(item.ClassAllowlist, []proto.Class{proto.Class_ClassPriest}) {
+		t.Fatalf("Expected the class allowlist to be replaced, got %v", item.ClassAllowlist)
+	}
+	if item.Unique || len(item.Sources) != 0 {
+		t.Fatalf("Expected unique and sources to be reset, got %v and %v", item.Unique, item.Sources)
+	}
+	if !slices.Equal(db.Items[2].ClassAllowlist, []proto.Class{proto.Class_ClassMage}) {
+		t.Fatalf("Expected items missing from the patch to be kept, got %v", db.Items[2])
+	}
+	if db.Items[5].GetName() != "Added" {
+		t.Fatalf("Expected new items to be added, got %v", db.Items[5])
+	}
+	if rune := db.Runes[3]; rune.Name != "Rune" || !slices.Equal(rune.ClassAllowlist, []proto.Class{proto.Class_ClassWarlock}) {
+		t.Fatalf("Expected the rune class allowlist to be replaced, got %v", rune)
+	}
+	if _, ok := db.Runes[4]; ok {
+		t.Fatalf("Expected rune 4 to be removed")
+	}
+}
+
+func TestValidateDatabasePatch(t *testing.T) {
+	for _, patch := range []*proto.UIDatabasePatch{
+		{Items: []*proto.UIItem{{Name: "No ID"}}},
+		{Enchants: []*proto.UIEnchant{{EffectId: 1}}},
+		{ItemResets: []*proto.UIDatabasePatch_FieldReset{{Id: 1, Fields: []string{"not_a_field"}}}},
+		{ItemResets: []*proto.UIDatabasePatch_FieldReset{{Id: 1, Fields: []string{"id"}}}},
+	} {
+		if err := ValidateDatabasePatch(patch); err == nil {
+			t.Fatalf("Expected %v to be invalid", patch)
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -767,6 +768,7 @@ func (item WowheadItemResponse) ToItemProto() *proto.UIItem {
 		RequiredProfession: item.GetRequiredProfession(),
 		SetName:            item.GetItemSetName(),
 		SetId:              int32(item.GetItemSetID()),
+		HasEffect:          len(item.GetEffectLines()) > 0,
 	}
 
 	if item.GetRequiredProfession() != proto.Profession_ProfessionUnknown {
@@ -782,6 +784,53 @@ func (item WowheadItemResponse) ToItemProto() *proto.UIItem {
 	return itemProto
 }
 
+var effectLineRegex = regexp.MustCompile(`(Equip|Use|Chance on hit): (.*?)</span>`)
+var htmlTagRegex = regexp.MustCompile(`<[^>]*>`)
+
+// Every stat pattern parsed by GetStats and GetWeaponSkills. Equip lines matching one of these are plain stats.
+var statEffectRegexes = []*regexp.Regexp{
+	spellHealingRegex, spellPowerRegex, spellPowerRegex2, spellPowerRegex3,
+	arcaneSpellPowerRegex, fireSpellPowerRegex, frostSpellPowerRegex, holySpellPowerRegex, natureSpellPowerRegex, shadowSpellPowerRegex,
+	hitRegex, hitRegex2, physicalHitRegex, spellHitRegex,
+	critRegex, critRegex2, spellCritRegex, meleeCritRegex,
+	hasteRegex, spellHasteRegex1, spellHasteRegex2, meleeHasteRegex,
+	spellPenetrationRegex, mp5Regex, attackPowerRegex, attackPowerRegex2,
+	rangedAttackPowerRegex, rangedAttackPowerRegex2, rangedAttackPowerRegex3, feralAttackPowerRegex,
+	armorPenetrationRegex, armorPenetrationRegex2, expertiseRegex,
+	axesSkill, swordsSkill, daggersSkill, unarmedSkill, macesSkill,
+	twoHandedAxesSkill, twoHandedSwordsSkill, twoHandedMacesSkill, stavesSkill, polearmsSkill,
+	thrownSkill, bowsSkill, crossbowsSkill, gunsSkill, feralCombatSkill,
+	defenseRegex, blockRegex, blockValueRegex, dodgeRegex, parryRegex, resilienceRegex,
+	arcaneResistanceRegex, fireResistanceRegex, frostResistanceRegex, natureResistanceRegex, shadowResistanceRegex,
+	physicalBonusDamageRegex, periodicBonusDamagePctRegex,
+}
+
+// Returns the Equip, Use and Chance on hit lines of the tooltip, excluding set bonuses
+// and equip lines which are already parsed as stats.
+func (item WowheadItemResponse) GetEffectLines() []string {
+	var lines []string
+	for _, match := range effectLineRegex.FindAllStringSubmatch(item.TooltipWithoutSetBonus(), -1) {
+		if match[1] == "Equip" && slices.ContainsFunc(statEffectRegexes, func(pattern *regexp.Regexp) bool {
+			return pattern.MatchString(match[2])
+		}) {
+			continue
+		}
+		lines = append(lines, match[1]+": "+strings.TrimSpace(htmlTagRegex.ReplaceAllString(match[2], "")))
+	}
+	return lines
+}
+
+var setBonusLineRegex = regexp.MustCompile(`(\([0-9]+\)) Set ?: (.*?)</`)
+
+// Returns the set bonus lines of the tooltip, e.g. "(2) Set: Increases ...".
+func (item WowheadItemResponse) GetSetBonusLines() []string {
+	var lines []string
+	for _, match := range setBonusLineRegex.FindAllStringSubmatch(item.Tooltip, -1) {
+		lines = append(lines, match[1]+" Set: "+strings.TrimSpace(htmlTagRegex.ReplaceAllString(match[2], "")))
+	}
+	return lines
+}
+
 var itemSetNameRegex = regexp.MustCompile(fmt.Sprintf(`<a href="\/%s[\-a-z]*\/item-set=-?([0-9]+)\/(.*)" class="q">([^<]+)<`, core.WowheadBranch))
 
 func (item WowheadItemResponse) GetItemSetID() int {
@@ -146,8 +146,18 @@ func WriteProtoArrayToBuffer[T googleProto.Message](arr []T, buffer *bytes.Buffe
 	buffer.WriteString("]")
 }
 
+var webRequestsDisabled = false
+
+// Makes all subsequent web requests fail, to guarantee a step only uses local inputs.
+func DisableWebRequests() {
+	webRequestsDisabled = true
+}
+
 // Fetches web results a single url, and returns the page contents as a string.
 func ReadWeb(url string) (string, error) {
+	if webRequestsDisabled {
+		return "", fmt.Errorf("web requests are disabled, cannot fetch %s", url)
+	}
 	resp, err := http.Get(url)
 	if err != nil {
 		return "", err
@@ -12,13 +12,18 @@ import {
 
 import * as PresetUtils from '../core/preset_utils.js';
 
+import Phase5APL from './apls/phase_5.apl.json';
 import BlankGear from './gear_sets/blank.gear.json';
+import Phase5Gear from './gear_sets/phase_5.gear.json';
 
 // Preset options for this spec.
 // Eventually we will import these values for the raid sim too, so its good to
 // keep them in a separate file.
 
 export const DefaultGear = PresetUtils.makePresetGear('Blank', BlankGear);
+export const GearPhase5 = PresetUtils.makePresetGear('Phase 5', Phase5Gear, { customCondition: player => player.getLevel() === 60 });
+
+export const APLPhase5 = PresetUtils.makePresetAPLRotation('Phase 5', Phase5APL, { customCondition: player => player.getLevel() === 60 });
 
 // Default talents. Uses the wowhead calculator format, make the talents on
 // https://wowhead.com/classic/talent-calc and copy the numbers in the url.
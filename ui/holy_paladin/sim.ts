@@ -85,9 +85,9 @@ const SPEC_CONFIG = registerSpecConfig(Spec.SpecHolyPaladin, {
 	presets: {
 		// Preset talents that the user can quickly select.
 		talents: [Presets.StandardTalents],
-		rotations: [],
+		rotations: [Presets.APLPhase5],
 		// Preset gear configurations that the user can quickly select.
-		gear: [Presets.DefaultGear],
+		gear: [Presets.DefaultGear, Presets.GearPhase5],
 	},
 
 	autoRotation: (_player: Player<Spec.SpecHolyPaladin>): APLRotation => {
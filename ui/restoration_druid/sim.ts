@@ -68,9 +68,9 @@ const SPEC_CONFIG = registerSpecConfig(Spec.SpecRestorationDruid, {
 	presets: {
 		// Preset talents that the user can quickly select.
 		talents: [Presets.CelestialFocusTalents, Presets.ThiccRestoTalents],
-		rotations: [],
+		rotations: [Presets.APLPhase4],
 		// Preset gear configurations that the user can quickly select.
-		gear: [Presets.DefaultGear],
+		gear: [Presets.DefaultGear, Presets.GearPhase4],
 	},
 
 	autoRotation: (_player: Player<Spec.SpecRestorationDruid>): APLRotation => {
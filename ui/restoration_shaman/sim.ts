@@ -76,9 +76,9 @@ const SPEC_CONFIG = registerSpecConfig(Spec.SpecRestorationShaman, {
 	presets: {
 		// Preset talents that the user can quickly select.
 		talents: [Presets.RaidHealingTalents, Presets.TankHealingTalents],
-		rotations: [],
+		rotations: [Presets.APLPhase4],
 		// Preset gear configurations that the user can quickly select.
-		gear: [Presets.DefaultGear],
+		gear: [Presets.DefaultGear, Presets.GearPhase4],
 	},
 
 	autoRotation: (_player: Player<Spec.SpecRestorationShaman>): APLRotation => {